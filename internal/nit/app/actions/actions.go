@@ -20,6 +20,41 @@ const (
 	ActionMenuLeft
 	ActionUndoLastCommit
 	ActionAbortRebase
+	ActionToggleLine
+	ActionDiscardSelection
+	ActionStashPush
+	ActionStashApply
+	ActionStashPop
+	ActionStashDrop
+	ActionBranchDelete
+	ActionBranchRename
+	ActionBranchUpstream
+	ActionBranchUnsetUpstream
+	ActionBranchDeleteRemote
+	ActionTagCreate
+	ActionTagPush
+	ActionRebaseInteractive
+	ActionRebaseContinue
+	ActionRebaseSkip
+	ActionCommitAmend
+	ActionCancelOperation
+	ActionMerge
+	ActionMergeContinue
+	ActionMergeAbort
+	ActionContinueOperation
+	ActionSkipOperation
+	ActionAbortOperation
+	ActionCherryPick
+	ActionRevert
+	ActionReset
+	ActionUndoDiscard
+	ActionDiscardSnapshots
+	ActionFileMenu
+	ActionOpenInEditor
+	ActionCopyPath
+	ActionFileHistory
+	ActionMarkDown
+	ActionMarkUp
 )
 
 type OpKind int
@@ -36,20 +71,75 @@ const (
 	OpPush
 	OpUndoLastCommit
 	OpAbortRebase
+	OpStagePatch
+	OpUnstagePatch
+	OpDiscardPatch
+	OpStashPush
+	OpStashApply
+	OpStashPop
+	OpStashDrop
+	OpStashAndSwitch
+	OpSwitchBranchCarry
+	OpBranchDelete
+	OpBranchRename
+	OpBranchSetUpstream
+	OpBranchUnsetUpstream
+	OpBranchDeleteRemote
+	OpBranchTrack
+	OpTagCreate
+	OpTagDelete
+	OpTagPush
+	OpRebaseInteractive
+	OpRebaseContinue
+	OpRebaseSkip
+	OpMerge
+	OpMergeContinue
+	OpMergeAbort
+	OpResolveConflict
+	OpCherryPickContinue
+	OpCherryPickSkip
+	OpCherryPickAbort
+	OpRevertContinue
+	OpRevertSkip
+	OpRevertAbort
+	OpBisectSkip
+	OpBisectReset
+	OpCherryPick
+	OpRevert
+	OpReset
+	OpRestoreSnapshot
+	OpDiscardFile
+	OpIgnorePath
 )
 
 type Operation struct {
-	Kind          OpKind
-	Path          string
-	Message       string
-	CommitAll     bool
-	CommitAmend   bool
-	CommitSignoff bool
+	Kind              OpKind
+	Path              string
+	Ref               string
+	Target            string
+	Message           string
+	Patch             string
+	Todo              string
+	Content           string
+	MergeMode         string
+	Hashes            []string
+	ResetMode         string
+	CommitAll         bool
+	CommitAmend       bool
+	CommitSignoff     bool
+	CommitSign        bool
+	CommitSignDefault bool
+	IncludeUntracked  bool
+	Force             bool
+	NoVerify          bool
+	RecordOrigin      bool
+	Mainline          bool
 }
 
 type ApplyResult struct {
-	Quit           bool
-	Operations     []Operation
-	RefreshChanges bool
-	RefreshGraph   bool
+	Quit               bool
+	Operations         []Operation
+	RefreshChanges     bool
+	RefreshGraph       bool
+	RefreshRepoSummary bool
 }
@@ -21,41 +21,120 @@ type (
 )
 
 const (
-	ActionNone           = actionspkg.ActionNone
-	ActionQuit           = actionspkg.ActionQuit
-	ActionTogglePanel    = actionspkg.ActionTogglePanel
-	ActionFocusCommand   = actionspkg.ActionFocusCommand
-	ActionMoveUp         = actionspkg.ActionMoveUp
-	ActionMoveDown       = actionspkg.ActionMoveDown
-	ActionToggleOne      = actionspkg.ActionToggleOne
-	ActionStageAll       = actionspkg.ActionStageAll
-	ActionUnstageAll     = actionspkg.ActionUnstageAll
-	ActionDiscardAll     = actionspkg.ActionDiscardAll
-	ActionPull           = actionspkg.ActionPull
-	ActionFetch          = actionspkg.ActionFetch
-	ActionPush           = actionspkg.ActionPush
-	ActionMenuRight      = actionspkg.ActionMenuRight
-	ActionMenuLeft       = actionspkg.ActionMenuLeft
-	ActionUndoLastCommit = actionspkg.ActionUndoLastCommit
-	ActionAbortRebase    = actionspkg.ActionAbortRebase
+	ActionNone                = actionspkg.ActionNone
+	ActionQuit                = actionspkg.ActionQuit
+	ActionTogglePanel         = actionspkg.ActionTogglePanel
+	ActionFocusCommand        = actionspkg.ActionFocusCommand
+	ActionMoveUp              = actionspkg.ActionMoveUp
+	ActionMoveDown            = actionspkg.ActionMoveDown
+	ActionToggleOne           = actionspkg.ActionToggleOne
+	ActionStageAll            = actionspkg.ActionStageAll
+	ActionUnstageAll          = actionspkg.ActionUnstageAll
+	ActionDiscardAll          = actionspkg.ActionDiscardAll
+	ActionPull                = actionspkg.ActionPull
+	ActionFetch               = actionspkg.ActionFetch
+	ActionPush                = actionspkg.ActionPush
+	ActionMenuRight           = actionspkg.ActionMenuRight
+	ActionMenuLeft            = actionspkg.ActionMenuLeft
+	ActionUndoLastCommit      = actionspkg.ActionUndoLastCommit
+	ActionAbortRebase         = actionspkg.ActionAbortRebase
+	ActionToggleLine          = actionspkg.ActionToggleLine
+	ActionDiscardSelection    = actionspkg.ActionDiscardSelection
+	ActionBranchDelete        = actionspkg.ActionBranchDelete
+	ActionBranchRename        = actionspkg.ActionBranchRename
+	ActionBranchUpstream      = actionspkg.ActionBranchUpstream
+	ActionBranchUnsetUpstream = actionspkg.ActionBranchUnsetUpstream
+	ActionBranchDeleteRemote  = actionspkg.ActionBranchDeleteRemote
+	ActionTagCreate           = actionspkg.ActionTagCreate
+	ActionTagPush             = actionspkg.ActionTagPush
+	ActionRebaseInteractive   = actionspkg.ActionRebaseInteractive
+	ActionRebaseContinue      = actionspkg.ActionRebaseContinue
+	ActionRebaseSkip          = actionspkg.ActionRebaseSkip
+	ActionCommitAmend         = actionspkg.ActionCommitAmend
+	ActionCancelOperation     = actionspkg.ActionCancelOperation
+	ActionMerge               = actionspkg.ActionMerge
+	ActionMergeContinue       = actionspkg.ActionMergeContinue
+	ActionMergeAbort          = actionspkg.ActionMergeAbort
+	ActionContinueOperation   = actionspkg.ActionContinueOperation
+	ActionSkipOperation       = actionspkg.ActionSkipOperation
+	ActionAbortOperation      = actionspkg.ActionAbortOperation
+	ActionCherryPick          = actionspkg.ActionCherryPick
+	ActionRevert              = actionspkg.ActionRevert
+	ActionReset               = actionspkg.ActionReset
+	ActionUndoDiscard         = actionspkg.ActionUndoDiscard
+	ActionDiscardSnapshots    = actionspkg.ActionDiscardSnapshots
+	ActionFileMenu            = actionspkg.ActionFileMenu
+	ActionOpenInEditor        = actionspkg.ActionOpenInEditor
+	ActionCopyPath            = actionspkg.ActionCopyPath
+	ActionFileHistory         = actionspkg.ActionFileHistory
+	ActionMarkDown            = actionspkg.ActionMarkDown
+	ActionMarkUp              = actionspkg.ActionMarkUp
+	ActionStashPush           = actionspkg.ActionStashPush
+	ActionStashApply          = actionspkg.ActionStashApply
+	ActionStashPop            = actionspkg.ActionStashPop
+	ActionStashDrop           = actionspkg.ActionStashDrop
 
-	OpStagePath      = actionspkg.OpStagePath
-	OpUnstagePath    = actionspkg.OpUnstagePath
-	OpStageAll       = actionspkg.OpStageAll
-	OpUnstageAll     = actionspkg.OpUnstageAll
-	OpDiscardAll     = actionspkg.OpDiscardAll
-	OpCommit         = actionspkg.OpCommit
-	OpPull           = actionspkg.OpPull
-	OpFetch          = actionspkg.OpFetch
-	OpPush           = actionspkg.OpPush
-	OpUndoLastCommit = actionspkg.OpUndoLastCommit
-	OpAbortRebase    = actionspkg.OpAbortRebase
+	OpStagePath           = actionspkg.OpStagePath
+	OpUnstagePath         = actionspkg.OpUnstagePath
+	OpStageAll            = actionspkg.OpStageAll
+	OpUnstageAll          = actionspkg.OpUnstageAll
+	OpDiscardAll          = actionspkg.OpDiscardAll
+	OpCommit              = actionspkg.OpCommit
+	OpPull                = actionspkg.OpPull
+	OpFetch               = actionspkg.OpFetch
+	OpPush                = actionspkg.OpPush
+	OpUndoLastCommit      = actionspkg.OpUndoLastCommit
+	OpAbortRebase         = actionspkg.OpAbortRebase
+	OpStagePatch          = actionspkg.OpStagePatch
+	OpUnstagePatch        = actionspkg.OpUnstagePatch
+	OpDiscardPatch        = actionspkg.OpDiscardPatch
+	OpStashPush           = actionspkg.OpStashPush
+	OpStashApply          = actionspkg.OpStashApply
+	OpStashPop            = actionspkg.OpStashPop
+	OpStashDrop           = actionspkg.OpStashDrop
+	OpStashAndSwitch      = actionspkg.OpStashAndSwitch
+	OpSwitchBranchCarry   = actionspkg.OpSwitchBranchCarry
+	OpBranchDelete        = actionspkg.OpBranchDelete
+	OpBranchRename        = actionspkg.OpBranchRename
+	OpBranchSetUpstream   = actionspkg.OpBranchSetUpstream
+	OpBranchUnsetUpstream = actionspkg.OpBranchUnsetUpstream
+	OpBranchDeleteRemote  = actionspkg.OpBranchDeleteRemote
+	OpBranchTrack         = actionspkg.OpBranchTrack
+	OpTagCreate           = actionspkg.OpTagCreate
+	OpTagDelete           = actionspkg.OpTagDelete
+	OpTagPush             = actionspkg.OpTagPush
+	OpRebaseInteractive   = actionspkg.OpRebaseInteractive
+	OpRebaseContinue      = actionspkg.OpRebaseContinue
+	OpRebaseSkip          = actionspkg.OpRebaseSkip
+	OpMerge               = actionspkg.OpMerge
+	OpMergeContinue       = actionspkg.OpMergeContinue
+	OpMergeAbort          = actionspkg.OpMergeAbort
+	OpResolveConflict     = actionspkg.OpResolveConflict
+	OpCherryPickContinue  = actionspkg.OpCherryPickContinue
+	OpCherryPickSkip      = actionspkg.OpCherryPickSkip
+	OpCherryPickAbort     = actionspkg.OpCherryPickAbort
+	OpRevertContinue      = actionspkg.OpRevertContinue
+	OpRevertSkip          = actionspkg.OpRevertSkip
+	OpRevertAbort         = actionspkg.OpRevertAbort
+	OpBisectSkip          = actionspkg.OpBisectSkip
+	OpBisectReset         = actionspkg.OpBisectReset
+	OpCherryPick          = actionspkg.OpCherryPick
+	OpRevert              = actionspkg.OpRevert
+	OpReset               = actionspkg.OpReset
+	OpRestoreSnapshot     = actionspkg.OpRestoreSnapshot
+	OpDiscardFile         = actionspkg.OpDiscardFile
+	OpIgnorePath          = actionspkg.OpIgnorePath
 
 	FocusCommand    = statepkg.FocusCommand
 	FocusChanges    = statepkg.FocusChanges
 	FocusGraph      = statepkg.FocusGraph
 	FocusBranches   = statepkg.FocusBranches
 	FocusCommandLog = statepkg.FocusCommandLog
+	FocusDiff       = statepkg.FocusDiff
+	FocusStash      = statepkg.FocusStash
+
+	CommitSubjectLimit = statepkg.CommitSubjectLimit
+	CommitBodyLimit    = statepkg.CommitBodyLimit
 )
 
 func New(keys Keymap) AppState {
@@ -14,18 +14,43 @@ type Keymap struct {
 
 func DefaultKeymap() Keymap {
 	return Keymap{bindings: map[actions.Action][]string{
-		actions.ActionQuit:         {"ctrl+c", "q"},
-		actions.ActionTogglePanel:  {"tab"},
-		actions.ActionFocusCommand: {"c"},
-		actions.ActionMoveDown:     {"down", "j"},
-		actions.ActionMoveUp:       {"up", "k"},
-		actions.ActionToggleOne:    {"enter"},
-		actions.ActionStageAll:     {"s"},
-		actions.ActionUnstageAll:   {"u"},
-		actions.ActionFetch:        {"f"},
-		actions.ActionPush:         {"p", "ctrl+p"},
-		actions.ActionMenuRight:    {"right", "l"},
-		actions.ActionMenuLeft:     {"left", "h"},
+		actions.ActionQuit:               {"ctrl+c", "q"},
+		actions.ActionTogglePanel:        {"tab"},
+		actions.ActionFocusCommand:       {"c"},
+		actions.ActionMoveDown:           {"down", "j"},
+		actions.ActionMoveUp:             {"up", "k"},
+		actions.ActionToggleOne:          {"enter"},
+		actions.ActionStageAll:           {"s"},
+		actions.ActionUnstageAll:         {"u"},
+		actions.ActionFetch:              {"f"},
+		actions.ActionPush:               {"p", "ctrl+p"},
+		actions.ActionMenuRight:          {"right", "l"},
+		actions.ActionMenuLeft:           {"left", "h"},
+		actions.ActionToggleLine:         {"space"},
+		actions.ActionDiscardSelection:   {"d"},
+		actions.ActionStashPush:          {"S"},
+		actions.ActionStashApply:         {"a"},
+		actions.ActionStashPop:           {"g"},
+		actions.ActionStashDrop:          {"x"},
+		actions.ActionBranchDelete:       {"D"},
+		actions.ActionBranchRename:       {"R"},
+		actions.ActionBranchUpstream:     {"U"},
+		actions.ActionBranchDeleteRemote: {"X"},
+		actions.ActionTagCreate:          {"T"},
+		actions.ActionTagPush:            {"P"},
+		actions.ActionRebaseInteractive:  {"i"},
+		actions.ActionCommitAmend:        {"A"},
+		actions.ActionCancelOperation:    {"ctrl+g"},
+		actions.ActionMerge:              {"M"},
+		actions.ActionCherryPick:         {"C"},
+		actions.ActionRevert:             {"V"},
+		actions.ActionReset:              {"ctrl+r"},
+		actions.ActionUndoDiscard:        {"ctrl+z"},
+		actions.ActionFileMenu:           {"m"},
+		actions.ActionOpenInEditor:       {"e"},
+		actions.ActionCopyPath:           {"y"},
+		actions.ActionMarkDown:           {"shift+down", "J"},
+		actions.ActionMarkUp:             {"shift+up", "K"},
 	}}
 }
 
@@ -48,6 +73,42 @@ func LoadKeymap(cfg config.KeyConfig) (Keymap, string) {
 	merge(actions.ActionPush, cfg.Push)
 	merge(actions.ActionMenuRight, cfg.MenuRight)
 	merge(actions.ActionMenuLeft, cfg.MenuLeft)
+	merge(actions.ActionToggleLine, cfg.ToggleLine)
+	merge(actions.ActionDiscardSelection, cfg.DiscardSelection)
+	merge(actions.ActionStashPush, cfg.StashPush)
+	merge(actions.ActionStashApply, cfg.StashApply)
+	merge(actions.ActionStashPop, cfg.StashPop)
+	merge(actions.ActionStashDrop, cfg.StashDrop)
+	merge(actions.ActionBranchDelete, cfg.BranchDelete)
+	merge(actions.ActionBranchRename, cfg.BranchRename)
+	merge(actions.ActionBranchUpstream, cfg.BranchUpstream)
+	merge(actions.ActionBranchUnsetUpstream, cfg.BranchUnsetUpstream)
+	merge(actions.ActionBranchDeleteRemote, cfg.BranchDeleteRemote)
+	merge(actions.ActionTagCreate, cfg.TagCreate)
+	merge(actions.ActionTagPush, cfg.TagPush)
+	merge(actions.ActionRebaseInteractive, cfg.RebaseInteractive)
+	merge(actions.ActionRebaseContinue, cfg.RebaseContinue)
+	merge(actions.ActionRebaseSkip, cfg.RebaseSkip)
+	merge(actions.ActionAbortRebase, cfg.RebaseAbort)
+	merge(actions.ActionCommitAmend, cfg.CommitAmend)
+	merge(actions.ActionCancelOperation, cfg.CancelOperation)
+	merge(actions.ActionMerge, cfg.Merge)
+	merge(actions.ActionMergeContinue, cfg.MergeContinue)
+	merge(actions.ActionMergeAbort, cfg.MergeAbort)
+	merge(actions.ActionContinueOperation, cfg.ContinueOperation)
+	merge(actions.ActionSkipOperation, cfg.SkipOperation)
+	merge(actions.ActionAbortOperation, cfg.AbortOperation)
+	merge(actions.ActionCherryPick, cfg.CherryPick)
+	merge(actions.ActionRevert, cfg.Revert)
+	merge(actions.ActionReset, cfg.Reset)
+	merge(actions.ActionUndoDiscard, cfg.UndoDiscard)
+	merge(actions.ActionDiscardSnapshots, cfg.DiscardSnapshots)
+	merge(actions.ActionFileMenu, cfg.FileMenu)
+	merge(actions.ActionOpenInEditor, cfg.OpenInEditor)
+	merge(actions.ActionCopyPath, cfg.CopyPath)
+	merge(actions.ActionFileHistory, cfg.FileHistory)
+	merge(actions.ActionMarkDown, cfg.MarkDown)
+	merge(actions.ActionMarkUp, cfg.MarkUp)
 
 	if err := validateKeyConflicts(km); err != nil {
 		return DefaultKeymap(), "invalid key config: " + err.Error()
@@ -69,6 +130,10 @@ func validateKeyConflicts(km Keymap) error {
 }
 
 func (k Keymap) Match(key string) actions.Action {
+	if key == " " {
+		// Bubble Tea reports the space bar as " "; bindings spell it "space".
+		key = "space"
+	}
 	for action, keys := range k.bindings {
 		for _, cand := range keys {
 			if cand == key {
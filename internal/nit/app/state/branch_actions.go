@@ -0,0 +1,110 @@
+package state
+
+import (
+	"strings"
+
+	"github.com/zGIKS/nit/internal/nit/app/actions"
+	"github.com/zGIKS/nit/internal/nit/git"
+)
+
+// selectedBranchForAction returns the branch under the Branches cursor when
+// that panel has focus.
+func (s *AppState) selectedBranchForAction() (string, bool) {
+	if s.Focus != FocusBranches {
+		return "", false
+	}
+	branch, ok := s.SelectedBranchName()
+	if !ok {
+		s.SetError("no local branch selected")
+		return "", false
+	}
+	return branch, true
+}
+
+// branchOperation runs kind against the branch under the Branches cursor.
+func (s *AppState) branchOperation(kind actions.OpKind) []actions.Operation {
+	branch, ok := s.selectedBranchForAction()
+	if !ok {
+		return nil
+	}
+	return []actions.Operation{{Kind: kind, Ref: branch}}
+}
+
+// OpenBranchDeleteDialog offers to delete the selected branch. Force delete
+// is offered next to the safe one since it drops commits not merged anywhere,
+// and is confirmed once more with the list of those commits.
+func (s *AppState) OpenBranchDeleteDialog() {
+	if b, ok := s.SelectedBranch(); ok && b.Kind == git.BranchTag && s.Focus == FocusBranches {
+		s.OpenTagDeleteDialog()
+		return
+	}
+	branch, ok := s.selectedBranchForAction()
+	if !ok {
+		return
+	}
+	deleted := actions.ApplyResult{RefreshGraph: true}
+	safe := deleted
+	safe.Operations = []actions.Operation{{Kind: actions.OpBranchDelete, Ref: branch}}
+	force := deleted
+	force.Operations = []actions.Operation{{Kind: actions.OpBranchDelete, Ref: branch, Force: true}}
+	s.OpenDialog(DialogState{
+		Title: "Delete branch " + branch,
+		Lines: []string{
+			"Delete only works if the branch is merged.",
+			"Force delete discards commits that are not merged anywhere.",
+		},
+		Options: []DialogOption{
+			{Label: "Delete " + branch, Result: safe},
+			{Label: "Force delete " + branch, Result: force},
+			{Label: "Cancel"},
+		},
+	})
+}
+
+func (s *AppState) OpenBranchRenamePrompt() {
+	branch, ok := s.selectedBranchForAction()
+	if !ok {
+		return
+	}
+	s.OpenPrompt(PromptState{
+		Kind:   PromptBranchRename,
+		Target: branch,
+		Title:  "Rename branch " + branch,
+		Label:  "New name",
+		Input:  branch,
+	})
+}
+
+func (s *AppState) OpenBranchUpstreamPrompt() {
+	branch, ok := s.selectedBranchForAction()
+	if !ok {
+		return
+	}
+	upstream := "origin/" + branch
+	if b, _ := s.SelectedBranch(); b.Upstream != "" {
+		upstream = b.Upstream
+	}
+	s.OpenPrompt(PromptState{
+		Kind:   PromptBranchUpstream,
+		Target: branch,
+		Title:  "Upstream of " + branch,
+		Label:  "Upstream branch (empty to unset)",
+		Input:  upstream,
+	})
+}
+
+// OpenTrackBranchPrompt offers to create a local branch tracking
+// remoteBranch, named after it without the remote prefix.
+func (s *AppState) OpenTrackBranchPrompt(remoteBranch string) {
+	name := remoteBranch
+	if i := strings.Index(name, "/"); i >= 0 {
+		name = name[i+1:]
+	}
+	s.OpenPrompt(PromptState{
+		Kind:   PromptBranchTrack,
+		Target: remoteBranch,
+		Title:  "Track " + remoteBranch,
+		Label:  "Local branch name",
+		Input:  name,
+	})
+}
@@ -1,21 +1,14 @@
 package state
 
-import "strings"
+import "github.com/zGIKS/nit/internal/nit/git"
 
 func (s *AppState) syncBranchCreateSources() {
-sources := make([]string, 0, len(s.Branches.Lines))
-for _, line := range s.Branches.Lines {
-name := strings.TrimSpace(line)
-if name == "" || strings.Contains(name, "No local branches") || strings.Contains(name, "Loading branches") || strings.Contains(name, "Not a git repo") {
+sources := make([]string, 0, len(s.Branches.Entries))
+for _, b := range s.Branches.Entries {
+if b.Kind != git.BranchLocal || b.Name == "" {
 continue
 }
-name = strings.TrimPrefix(name, "●")
-name = strings.TrimPrefix(name, "*")
-name = strings.TrimSpace(name)
-if name == "" {
-continue
-}
-sources = append(sources, name)
+sources = append(sources, b.Name)
 }
 s.BranchCreateSourceList = sources
 if s.BranchCreateSource == "" && s.BranchName != "" {
@@ -1,27 +1,138 @@
 package state
 
-import "strings"
+import (
+	"fmt"
 
+	"github.com/zGIKS/nit/internal/nit/git"
+)
+
+func (s AppState) SelectedBranchRow() (BranchRow, bool) {
+	if s.Branches.Cursor < 0 || s.Branches.Cursor >= len(s.Branches.Rows) {
+		return BranchRow{}, false
+	}
+	return s.Branches.Rows[s.Branches.Cursor], true
+}
+
+// SelectedBranch returns the ref under the Branches cursor: a local branch,
+// remote branch or tag.
+func (s AppState) SelectedBranch() (git.Branch, bool) {
+	row, ok := s.SelectedBranchRow()
+	if !ok || row.Kind != BranchRowRef {
+		return git.Branch{}, false
+	}
+	return row.Ref, true
+}
+
+// SelectedBranchName returns the local branch under the Branches cursor.
 func (s AppState) SelectedBranchName() (string, bool) {
-	if s.Branches.Cursor < 0 || s.Branches.Cursor >= len(s.Branches.Lines) {
+	b, ok := s.SelectedBranch()
+	if !ok || b.Kind != git.BranchLocal {
 		return "", false
 	}
-	name := normalizeBranchListLine(s.Branches.Lines[s.Branches.Cursor])
-	if name == "" {
+	return b.Name, true
+}
+
+// ActivateBranchRow handles Enter on the Branches panel. It toggles section
+// headers, offers to track remote branches and returns the local branch to
+// switch to, if any.
+func (s *AppState) ActivateBranchRow() (string, bool) {
+	row, ok := s.SelectedBranchRow()
+	if !ok {
 		return "", false
 	}
-	return name, true
+	switch {
+	case row.Kind == BranchRowSection:
+		s.ToggleBranchSection(row.Section)
+	case row.Kind == BranchRowRef && row.Ref.Kind == git.BranchLocal:
+		return row.Ref.Name, true
+	case row.Kind == BranchRowRef && row.Ref.Kind == git.BranchRemote:
+		s.OpenTrackBranchPrompt(row.Ref.Name)
+	}
+	return "", false
 }
 
-func normalizeBranchListLine(line string) string {
-	name := strings.TrimSpace(line)
-	if name == "" {
-		return ""
+func (s *AppState) ToggleBranchSection(kind git.BranchKind) {
+	switch kind {
+	case git.BranchRemote:
+		s.Branches.RemotesOpen = !s.Branches.RemotesOpen
+	case git.BranchTag:
+		s.Branches.TagsOpen = !s.Branches.TagsOpen
+	default:
+		return
 	}
-	if strings.Contains(name, "No local branches") || strings.Contains(name, "Loading branches") || strings.Contains(name, "Not a git repo") {
-		return ""
+	s.rebuildBranchRows()
+	for i, row := range s.Branches.Rows {
+		if row.Kind == BranchRowSection && row.Section == kind {
+			s.Branches.Cursor = i
+			break
+		}
+	}
+}
+
+// rebuildBranchRows lays out local branches, then the Remotes and Tags
+// sections, whose refs are only listed while the section is open.
+func (s *AppState) rebuildBranchRows() {
+	var local, remote, tags []git.Branch
+	for _, b := range s.Branches.Entries {
+		switch b.Kind {
+		case git.BranchLocal:
+			local = append(local, b)
+		case git.BranchRemote:
+			remote = append(remote, b)
+		case git.BranchTag:
+			tags = append(tags, b)
+		}
+	}
+	rows := make([]BranchRow, 0, len(s.Branches.Entries)+2)
+	lines := make([]string, 0, len(s.Branches.Entries)+2)
+	for _, b := range local {
+		marker := "  "
+		if b.Head {
+			marker = "● "
+		}
+		line := marker + b.Name
+		if b.UpstreamGone {
+			line += " (gone)"
+		} else if counts := AheadBehindText(b.Ahead, b.Behind); counts != "" {
+			line += " " + counts
+		}
+		rows = append(rows, BranchRow{Kind: BranchRowRef, Section: git.BranchLocal, Ref: b})
+		lines = append(lines, line)
+	}
+	if len(local) == 0 {
+		rows = append(rows, BranchRow{Kind: BranchRowNote})
+		lines = append(lines, "No local branches.")
+	}
+	section := func(kind git.BranchKind, title string, refs []git.Branch, open bool) {
+		if len(refs) == 0 {
+			return
+		}
+		arrow := "▸"
+		if open {
+			arrow = "▾"
+		}
+		rows = append(rows, BranchRow{Kind: BranchRowSection, Section: kind})
+		lines = append(lines, fmt.Sprintf("%s %s (%d)", arrow, title, len(refs)))
+		if !open {
+			return
+		}
+		for _, b := range refs {
+			rows = append(rows, BranchRow{Kind: BranchRowRef, Section: kind, Ref: b})
+			lines = append(lines, "  "+b.Name)
+		}
+	}
+	section(git.BranchRemote, "Remotes", remote, s.Branches.RemotesOpen)
+	section(git.BranchTag, "Tags", tags, s.Branches.TagsOpen)
+	s.Branches.Rows = rows
+	s.Branches.Lines = lines
+}
+
+// branchRowIndex finds the row showing b, or the header of its section.
+func (s AppState) branchRowIndex(b BranchRow) int {
+	for i, row := range s.Branches.Rows {
+		if row.Kind == b.Kind && row.Section == b.Section && row.Ref.Name == b.Ref.Name {
+			return i
+		}
 	}
-	name = strings.TrimPrefix(name, "●")
-	name = strings.TrimPrefix(name, "*")
-	return strings.TrimSpace(name)
+	return -1
 }
@@ -0,0 +1,177 @@
+package state
+
+import (
+	"fmt"
+
+	"github.com/zGIKS/nit/internal/nit/app/actions"
+	"github.com/zGIKS/nit/internal/nit/git"
+)
+
+// graphMark prefixes the graph lines of marked commits.
+const graphMark = "▸ "
+
+// ToggleGraphMark marks or unmarks the commit under the graph cursor for a
+// cherry-pick.
+func (s *AppState) ToggleGraphMark() {
+	c, ok := s.SelectedCommit()
+	if !ok {
+		return
+	}
+	if s.Graph.Marked[c.Hash] {
+		delete(s.Graph.Marked, c.Hash)
+		return
+	}
+	s.markGraphCommit()
+}
+
+// ExtendGraphMark marks the commit under the graph cursor, moves the cursor
+// by delta and marks the commit there too, so that holding the key marks a
+// range of commits.
+func (s *AppState) ExtendGraphMark(delta int) {
+	if s.Focus != FocusGraph {
+		return
+	}
+	s.markGraphCommit()
+	s.moveCursor(delta)
+	s.markGraphCommit()
+}
+
+func (s *AppState) markGraphCommit() {
+	c, ok := s.SelectedCommit()
+	if !ok {
+		return
+	}
+	if s.Graph.Marked == nil {
+		s.Graph.Marked = map[string]bool{}
+	}
+	s.Graph.Marked[c.Hash] = true
+}
+
+// GraphDisplayLines are the graph lines as shown, with a column for the mark
+// while any commit is marked.
+func (s AppState) GraphDisplayLines() []string {
+	if len(s.Graph.Marked) == 0 {
+		return s.Graph.Lines
+	}
+	lines := make([]string, len(s.Graph.Lines))
+	for i, line := range s.Graph.Lines {
+		prefix := "  "
+		if i < len(s.Graph.Commits) && s.Graph.Marked[s.Graph.Commits[i].Hash] {
+			prefix = graphMark
+		}
+		lines[i] = prefix + line
+	}
+	return lines
+}
+
+// pruneGraphMarks drops the marks of commits no longer in the graph.
+func (s *AppState) pruneGraphMarks() {
+	if len(s.Graph.Marked) == 0 {
+		return
+	}
+	shown := make(map[string]bool, len(s.Graph.Commits))
+	for _, c := range s.Graph.Commits {
+		shown[c.Hash] = true
+	}
+	for hash := range s.Graph.Marked {
+		if !shown[hash] {
+			delete(s.Graph.Marked, hash)
+		}
+	}
+}
+
+// pickedCommits are the marked commits, oldest first, or the commit under the
+// cursor when none is marked.
+func (s AppState) pickedCommits() []git.Commit {
+	var picked []git.Commit
+	for i := len(s.Graph.Commits) - 1; i >= 0; i-- {
+		if c := s.Graph.Commits[i]; s.Graph.Marked[c.Hash] {
+			picked = append(picked, c)
+		}
+	}
+	if len(picked) == 0 {
+		if c, ok := s.SelectedCommit(); ok {
+			picked = append(picked, c)
+		}
+	}
+	return picked
+}
+
+// OpenCherryPickDialog offers to apply the marked commits, or the selected
+// one, on top of HEAD.
+func (s *AppState) OpenCherryPickDialog() {
+	if s.Focus != FocusGraph {
+		s.SetError("select the commits to cherry-pick in the graph")
+		return
+	}
+	picked := s.pickedCommits()
+	if len(picked) == 0 {
+		s.SetError("no commit selected")
+		return
+	}
+	hashes := make([]string, 0, len(picked))
+	mainline := false
+	lines := []string{fmt.Sprintf("%d commit(s), oldest first:", len(picked))}
+	const maxCommits = 5
+	for i, c := range picked {
+		hashes = append(hashes, c.Hash)
+		mainline = mainline || len(c.Parents) > 1
+		if i < maxCommits {
+			lines = append(lines, "  "+c.ShortHash+" "+c.Subject)
+		} else if i == maxCommits {
+			lines = append(lines, fmt.Sprintf("  ... and %d more", len(picked)-maxCommits))
+		}
+	}
+	if mainline {
+		lines = append(lines, "Merge commits are applied against their first parent.")
+	}
+	picks := actions.ApplyResult{RefreshChanges: true, RefreshGraph: true, RefreshRepoSummary: true}
+	option := func(label string, recordOrigin bool) DialogOption {
+		res := picks
+		res.Operations = []actions.Operation{{Kind: actions.OpCherryPick, Hashes: hashes, RecordOrigin: recordOrigin, Mainline: mainline}}
+		return DialogOption{Label: label, Result: res}
+	}
+	s.OpenDialog(DialogState{
+		Title: "Cherry-pick onto " + s.BranchName,
+		Lines: lines,
+		Options: []DialogOption{
+			option("Cherry-pick", false),
+			option("Cherry-pick with -x (note the original commit in the message)", true),
+			{Label: "Cancel"},
+		},
+	})
+}
+
+// OpenRevertDialog offers to commit the inverse of the selected commit.
+func (s *AppState) OpenRevertDialog() {
+	if s.Focus != FocusGraph {
+		s.SetError("select the commit to revert in the graph")
+		return
+	}
+	c, ok := s.SelectedCommit()
+	if !ok {
+		s.SetError("no commit selected")
+		return
+	}
+	mainline := len(c.Parents) > 1
+	lines := []string{
+		"  " + c.Subject,
+		"A new commit on " + s.BranchName + " undoes its changes.",
+	}
+	if mainline {
+		lines = append(lines, "It is a merge commit, so it is reverted against its first parent.")
+	}
+	s.OpenDialog(DialogState{
+		Title: "Revert " + c.ShortHash,
+		Lines: lines,
+		Options: []DialogOption{
+			{Label: "Revert", Result: actions.ApplyResult{
+				Operations:         []actions.Operation{{Kind: actions.OpRevert, Ref: c.Hash, Mainline: mainline}},
+				RefreshChanges:     true,
+				RefreshGraph:       true,
+				RefreshRepoSummary: true,
+			}},
+			{Label: "Cancel"},
+		},
+	})
+}
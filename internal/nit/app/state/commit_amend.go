@@ -0,0 +1,100 @@
+package state
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ToggleCommitAmend turns amending on or off from inside the commit editor.
+// Turning it off brings back the message typed before HEAD's was loaded.
+func (s *AppState) ToggleCommitAmend() {
+	if s.Command.CommitAmend {
+		s.cancelAmend()
+		return
+	}
+	s.Command.CommitAmend = true
+}
+
+// AmendMessageNeeded reports whether amend is on but HEAD's message has not
+// been requested yet.
+func (s AppState) AmendMessageNeeded() bool {
+	return s.Command.CommitAmend && !s.Command.AmendLoading && s.Command.AmendHash == ""
+}
+
+func (s *AppState) BeginAmendLoad() {
+	s.Command.AmendLoading = true
+}
+
+// SetAmendCommit replaces the editor content with the message of the commit
+// being amended, keeping the current input to restore if amend is cancelled.
+// pushedTo is the upstream that already has the commit, or "".
+func (s *AppState) SetAmendCommit(shortHash, message, pushedTo string) {
+	if !s.Command.CommitAmend || !s.Command.AmendLoading {
+		return
+	}
+	s.Command.AmendLoading = false
+	s.Command.AmendHash = shortHash
+	s.Command.AmendPushedTo = pushedTo
+	s.Command.AmendDraft = s.Command.Input
+	s.Command.Input = strings.TrimRight(message, " \t\n")
+	s.Command.SelectAll = false
+	s.Command.Cursor = len([]rune(s.CommitEditorLines()[0]))
+}
+
+// AmendLoadFailed turns amend off again, e.g. when there is no commit yet.
+func (s *AppState) AmendLoadFailed(msg string) {
+	s.cancelAmend()
+	s.SetError(msg)
+}
+
+func (s *AppState) cancelAmend() {
+	if s.Command.AmendHash != "" {
+		s.Command.Input = s.Command.AmendDraft
+		s.Command.Cursor = len([]rune(s.Command.Input))
+		s.Command.SelectAll = false
+	}
+	s.Command.CommitAmend = false
+	s.clearAmendState()
+}
+
+func (s *AppState) clearAmendState() {
+	s.Command.AmendHash = ""
+	s.Command.AmendPushedTo = ""
+	s.Command.AmendDraft = ""
+	s.Command.AmendLoading = false
+}
+
+// CommitAmendLines describes a pending amend: the commit it rewrites, the
+// files that will be added to it and whether it was already pushed.
+func (s AppState) CommitAmendLines() []string {
+	if !s.Command.CommitAmend {
+		return nil
+	}
+	if s.Command.AmendHash == "" {
+		return []string{"Amending HEAD (loading message...)"}
+	}
+	entries := s.Changes.Staged
+	if s.Command.CommitAll {
+		entries = s.Changes.Entries
+	}
+	line := "Amending " + s.Command.AmendHash + " · message only"
+	if len(entries) > 0 {
+		const maxFiles = 3
+		paths := make([]string, 0, maxFiles)
+		for i, e := range entries {
+			if i == maxFiles {
+				break
+			}
+			paths = append(paths, e.Path)
+		}
+		line = "Amending " + s.Command.AmendHash + " · adds " + strings.Join(paths, ", ")
+		if len(entries) > maxFiles {
+			line += fmt.Sprintf(" and %d more", len(entries)-maxFiles)
+		}
+	}
+	lines := []string{line}
+	if s.Command.AmendPushedTo != "" {
+		lines = append(lines, fmt.Sprintf("⚠ %s is already on %s; amending rewrites pushed history", s.Command.AmendHash, s.Command.AmendPushedTo))
+	}
+	return lines
+}
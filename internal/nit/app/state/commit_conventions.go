@@ -0,0 +1,170 @@
+package state
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+var (
+	conventionalSubjectRe = regexp.MustCompile(`^([A-Za-z]+)(\(([^()]*)\))?(!)?: \S`)
+	conventionalPrefixRe  = regexp.MustCompile(`^([A-Za-z]+)(\(([^()]*)\))?(!)?:\s*`)
+	trailerLineRe         = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9-]*: \S`)
+)
+
+func (s *AppState) SetCommitConventions(conventional bool, types, trailers []string) {
+	s.Command.Conventional = conventional
+	s.Command.Types = types
+	s.Command.Trailers = trailers
+}
+
+// SetCommitTemplate stores the commit template and fills the editor right
+// away if it already has focus.
+func (s *AppState) SetCommitTemplate(template string) {
+	s.Command.Template = template
+	s.Command.WasFocused = false
+	s.SyncCommitTemplate()
+}
+
+// SyncCommitTemplate fills the empty commit editor with the commit template
+// when it gains focus. The cursor goes to the start so the subject can be
+// typed right away.
+func (s *AppState) SyncCommitTemplate() {
+	focused := s.Focus == FocusCommand
+	if focused && !s.Command.WasFocused && s.Command.Input == "" && s.Command.Template != "" {
+		s.Command.Input = s.Command.Template
+		s.Command.Cursor = 0
+		s.Command.SelectAll = false
+	}
+	s.Command.WasFocused = focused
+}
+
+// commitTemplateUnedited reports whether the message is still the template,
+// which git itself refuses to commit.
+func (s AppState) commitTemplateUnedited() bool {
+	return s.Command.Template != "" && strings.TrimSpace(s.Command.Input) == strings.TrimSpace(s.Command.Template)
+}
+
+// OpenCommitTrailerPicker lists the configured trailer keys; picking one asks
+// for its value and appends the trailer to the message.
+func (s *AppState) OpenCommitTrailerPicker() {
+	if len(s.Command.Trailers) == 0 {
+		s.SetError("no commit trailers configured")
+		return
+	}
+	options := make([]DialogOption, 0, len(s.Command.Trailers)+1)
+	for _, key := range s.Command.Trailers {
+		label := "Value"
+		switch strings.ToLower(key) {
+		case "co-authored-by", "reviewed-by", "signed-off-by", "acked-by":
+			label = "Name <email>"
+		case "refs", "fixes", "closes":
+			label = "Issue or reference"
+		}
+		options = append(options, DialogOption{
+			Label:  key,
+			Prompt: PromptState{Kind: PromptCommitTrailer, Value: key, Title: "Add " + key + " trailer", Label: label},
+		})
+	}
+	options = append(options, DialogOption{Label: "Cancel"})
+	s.OpenDialog(DialogState{
+		Title:   "Add trailer",
+		Lines:   []string{"Trailers are added at the end of the commit message."},
+		Options: options,
+	})
+}
+
+// addCommitTrailer appends "key: value" to the message. It joins an existing
+// trailer block at the end of the body, or starts a new paragraph.
+func (s *AppState) addCommitTrailer(key, value string) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return
+	}
+	trailer := key + ": " + value
+	text := strings.TrimRight(s.Command.Input, " \t\n")
+	lines := strings.Split(text, "\n")
+	switch {
+	case len(lines) == 1:
+		// CommitMessage puts the blank line after the subject.
+		text += "\n" + trailer
+	case trailerLineRe.MatchString(lines[len(lines)-1]):
+		text += "\n" + trailer
+	default:
+		text += "\n\n" + trailer
+	}
+	s.Command.Input = text
+	s.Command.SelectAll = false
+	s.Command.Cursor = min(s.Command.Cursor, len([]rune(text)))
+}
+
+// OpenCommitTypePicker offers the Conventional Commits types, then asks for
+// an optional scope and whether the change is breaking.
+func (s *AppState) OpenCommitTypePicker() {
+	if !s.Command.Conventional {
+		s.SetError("Conventional Commits mode is off; set conventional = true under [commit]")
+		return
+	}
+	subject := s.CommitEditorLines()[0]
+	current, scope, breaking := "", "", false
+	if m := conventionalPrefixRe.FindStringSubmatch(subject); m != nil {
+		current, scope, breaking = m[1], m[3], m[4] != ""
+	}
+	options := make([]DialogOption, 0, len(s.Command.Types)+1)
+	cursor := 0
+	for i, typ := range s.Command.Types {
+		if typ == current {
+			cursor = i
+		}
+		options = append(options, DialogOption{
+			Label: typ,
+			Prompt: PromptState{
+				Kind:        PromptCommitScope,
+				Value:       typ,
+				Title:       "Commit type: " + typ,
+				Label:       "Scope (optional)",
+				Input:       scope,
+				OptionLabel: "Breaking change (!)",
+				Option:      breaking,
+			},
+		})
+	}
+	options = append(options, DialogOption{Label: "Cancel"})
+	s.OpenDialog(DialogState{
+		Title:   "Commit type",
+		Lines:   []string{"Pick the Conventional Commits type of this change."},
+		Options: options,
+	})
+	s.Dialog.Cursor = cursor
+}
+
+// setConventionalPrefix replaces the "type(scope)!: " prefix of the subject.
+func (s *AppState) setConventionalPrefix(typ, scope string, breaking bool) {
+	prefix := typ
+	if scope = strings.TrimSpace(scope); scope != "" {
+		prefix += "(" + scope + ")"
+	}
+	if breaking {
+		prefix += "!"
+	}
+	prefix += ": "
+	lines := s.CommitEditorLines()
+	lines[0] = prefix + conventionalPrefixRe.ReplaceAllString(lines[0], "")
+	s.Command.Input = strings.Join(lines, "\n")
+	s.Command.SelectAll = false
+	s.Command.Cursor = len([]rune(lines[0]))
+}
+
+// conventionalSubjectError explains why a subject is not a valid Conventional
+// Commits header, or returns "" when it is.
+func (s AppState) conventionalSubjectError(subject string) string {
+	m := conventionalSubjectRe.FindStringSubmatch(subject)
+	if m == nil {
+		return "subject must look like type(scope): description"
+	}
+	if !slices.Contains(s.Command.Types, m[1]) {
+		return fmt.Sprintf("unknown commit type %q (allowed: %s)", m[1], strings.Join(s.Command.Types, ", "))
+	}
+	return ""
+}
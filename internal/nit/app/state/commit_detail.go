@@ -0,0 +1,139 @@
+package state
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/zGIKS/nit/internal/nit/git"
+)
+
+const commitDetailDateLayout = "2006-01-02 15:04:05 -0700"
+
+func (s AppState) SelectedCommit() (git.Commit, bool) {
+	if s.Graph.Cursor < 0 || s.Graph.Cursor >= len(s.Graph.Commits) {
+		return git.Commit{}, false
+	}
+	return s.Graph.Commits[s.Graph.Cursor], true
+}
+
+func (s *AppState) OpenCommitDetail(hash, title string) {
+	s.CloseMenu()
+	s.CloseBranchCreate()
+	s.CommitDetail = CommitDetailState{Open: true, Hash: hash, Title: title}
+}
+
+// CommitDetailTarget reports the commit whose details still have to be
+// loaded.
+func (s AppState) CommitDetailTarget() (string, bool) {
+	cd := s.CommitDetail
+	return cd.Hash, cd.Open && !cd.Loaded && !cd.Loading
+}
+
+func (s *AppState) BeginCommitDetailLoad() {
+	s.CommitDetail.Loading = true
+}
+
+func (s *AppState) CloseCommitDetail() {
+	s.CommitDetail = CommitDetailState{}
+}
+
+func (s *AppState) SetCommitDetail(d git.CommitDetail) {
+	if !s.CommitDetail.Open || s.CommitDetail.Hash != d.Hash {
+		return
+	}
+	s.CommitDetail.Detail = d
+	s.CommitDetail.Loading = false
+	s.CommitDetail.Loaded = true
+	s.clampCommitDetail()
+}
+
+// CommitDetailFileTarget reports the changed file whose diff the detail view
+// should show.
+func (s AppState) CommitDetailFileTarget() (git.CommitFile, bool) {
+	cd := s.CommitDetail
+	if !cd.Open || !cd.Loaded || cd.FileCursor < 0 || cd.FileCursor >= len(cd.Detail.Files) {
+		return git.CommitFile{}, false
+	}
+	return cd.Detail.Files[cd.FileCursor], true
+}
+
+func (s *AppState) BeginCommitFileDiffLoad(path string) {
+	s.CommitDetail.Diff = DiffState{Path: path}
+}
+
+func (s *AppState) SetCommitFileDiff(hash string, d git.FileDiff) {
+	cd := &s.CommitDetail
+	if !cd.Open || cd.Hash != hash || cd.Diff.Path != d.Path {
+		return
+	}
+	cd.Diff.File = d
+	cd.Diff.Lines = d.Lines()
+	cd.Diff.Loaded = true
+	s.clampCommitDetail()
+}
+
+// MoveCommitDetailCursor moves through the file list or scrolls the diff,
+// whichever side has focus.
+func (s *AppState) MoveCommitDetailCursor(delta int) {
+	if s.CommitDetail.DiffFocus {
+		s.CommitDetail.Diff.Cursor += delta
+	} else {
+		s.CommitDetail.FileCursor += delta
+	}
+	s.clampCommitDetail()
+}
+
+func (s *AppState) ToggleCommitDetailFocus() {
+	s.CommitDetail.DiffFocus = !s.CommitDetail.DiffFocus
+}
+
+func (s *AppState) clampCommitDetail() {
+	page := s.commitDetailPageSize()
+	cd := &s.CommitDetail
+	clampScrollView(len(cd.Detail.Files), &cd.FileCursor, &cd.FileOffset, page)
+	clampScrollView(len(cd.Diff.Lines), &cd.Diff.Cursor, &cd.Diff.Offset, page)
+}
+
+// CommitDetailHeaderLines describes the commit above the file list: hash,
+// parents, refs, author and committer, signature status, then the full
+// message.
+func (s AppState) CommitDetailHeaderLines() []string {
+	d := s.CommitDetail.Detail
+	lines := []string{"commit " + d.Hash}
+	if len(d.Parents) > 0 {
+		lines = append(lines, "Parents:   "+strings.Join(shortHashes(d.Parents), " "))
+	}
+	if d.Refs != "" {
+		lines = append(lines, "Refs:      "+d.Refs)
+	}
+	lines = append(lines, fmt.Sprintf("Author:    %s <%s>  %s", d.AuthorName, d.AuthorEmail, d.AuthorDate.Format(commitDetailDateLayout)))
+	if d.CommitterName != d.AuthorName || d.CommitterEmail != d.AuthorEmail || !d.CommitDate.Equal(d.AuthorDate) {
+		lines = append(lines, fmt.Sprintf("Committer: %s <%s>  %s", d.CommitterName, d.CommitterEmail, d.CommitDate.Format(commitDetailDateLayout)))
+	}
+	if d.Signature != "" {
+		sig := "Signature: " + string(d.Signature)
+		if d.Signer != "" {
+			sig += "  " + d.Signer
+		}
+		if d.SigningKey != "" {
+			sig += "  key " + d.SigningKey
+		}
+		lines = append(lines, sig)
+	}
+	lines = append(lines, "")
+	for _, line := range strings.Split(d.Message, "\n") {
+		lines = append(lines, "    "+line)
+	}
+	return lines
+}
+
+func shortHashes(hashes []string) []string {
+	out := make([]string, 0, len(hashes))
+	for _, h := range hashes {
+		if len(h) > 7 {
+			h = h[:7]
+		}
+		out = append(out, h)
+	}
+	return out
+}
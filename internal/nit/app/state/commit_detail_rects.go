@@ -0,0 +1,87 @@
+package state
+
+func (s AppState) CommitDetailPanelRect() (x, y, w, h int) {
+	totalW := max(40, s.Viewport.Width)
+	totalH := max(12, s.Viewport.Height)
+	x, y = 2, 1
+	w = totalW - 2*x
+	h = totalH - 2*y
+	return x, y, w, h
+}
+
+// CommitDetailHeaderHeight is the height of the box holding the commit
+// metadata and message. It grows with the message up to a third of the panel;
+// longer messages are cut.
+func (s AppState) CommitDetailHeaderHeight() int {
+	_, _, _, ph := s.CommitDetailPanelRect()
+	rows := 1
+	if s.CommitDetail.Loaded {
+		rows = len(s.CommitDetailHeaderLines())
+	}
+	return min(rows+2, max(4, ph/3))
+}
+
+func (s AppState) CommitDetailPaneWidths() (filesW, diffW int) {
+	_, _, pw, _ := s.CommitDetailPanelRect()
+	filesW = max(24, pw/3)
+	if filesW > pw-20 {
+		filesW = max(12, pw-20)
+	}
+	diffW = pw - filesW - 1
+	return filesW, diffW
+}
+
+// CommitDetailBodyRect covers the file list and the diff below the header.
+func (s AppState) CommitDetailBodyRect() (x, y, w, h int) {
+	px, py, pw, ph := s.CommitDetailPanelRect()
+	headerH := s.CommitDetailHeaderHeight()
+	return px, py + headerH, pw, max(3, ph-headerH)
+}
+
+func (s AppState) commitDetailPageSize() int {
+	_, _, _, h := s.CommitDetailBodyRect()
+	return max(1, h-2)
+}
+
+func (s *AppState) CommitDetailClick(x, y int) bool {
+	if !s.CommitDetail.Open {
+		return false
+	}
+	px, py, pw, ph := s.CommitDetailPanelRect()
+	if x < px || x >= px+pw || y < py || y >= py+ph {
+		s.CloseCommitDetail()
+		return true
+	}
+	bx, by, _, bh := s.CommitDetailBodyRect()
+	if y < by || y >= by+bh {
+		return true
+	}
+	filesW, _ := s.CommitDetailPaneWidths()
+	idx, ok := boxContentLine(y, by, bh)
+	if x < bx+filesW {
+		s.CommitDetail.DiffFocus = false
+		if ok && s.CommitDetail.FileOffset+idx < len(s.CommitDetail.Detail.Files) {
+			s.CommitDetail.FileCursor = s.CommitDetail.FileOffset + idx
+		}
+	} else {
+		s.CommitDetail.DiffFocus = true
+		if ok && s.CommitDetail.Diff.Offset+idx < len(s.CommitDetail.Diff.Lines) {
+			s.CommitDetail.Diff.Cursor = s.CommitDetail.Diff.Offset + idx
+		}
+	}
+	s.clampCommitDetail()
+	return true
+}
+
+func (s *AppState) CommitDetailWheelAt(x, y, delta int) bool {
+	if !s.CommitDetail.Open || delta == 0 {
+		return false
+	}
+	bx, by, _, bh := s.CommitDetailBodyRect()
+	if y >= by && y < by+bh {
+		filesW, _ := s.CommitDetailPaneWidths()
+		s.CommitDetail.DiffFocus = x >= bx+filesW
+	}
+	s.MoveCommitDetailCursor(delta)
+	return true
+}
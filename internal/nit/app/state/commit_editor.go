@@ -0,0 +1,153 @@
+package state
+
+import "strings"
+
+const (
+	CommitSubjectLimit = 50
+	CommitBodyLimit    = 72
+
+	// commitEditorMaxRows caps how many content rows the focused Commit box
+	// grows to before it scrolls.
+	commitEditorMaxRows = 10
+)
+
+// CommitEditorLines splits the commit input into lines. The first line is the
+// subject, every following line belongs to the body.
+func (s AppState) CommitEditorLines() []string {
+	return strings.Split(s.Command.Input, "\n")
+}
+
+// CommitCursorLineCol returns the line and column (both in runes) of the
+// commit input cursor.
+func (s AppState) CommitCursorLineCol() (line, col int) {
+	return textLineCol(s.Command.Input, s.Command.Cursor)
+}
+
+// CommitMessage builds the message passed to git: the trimmed subject, then a
+// blank line and the body when there is one.
+func (s AppState) CommitMessage() string {
+	lines := s.CommitEditorLines()
+	subject := strings.TrimSpace(lines[0])
+	body := make([]string, 0, len(lines)-1)
+	for _, line := range lines[1:] {
+		body = append(body, strings.TrimRight(line, " \t"))
+	}
+	for len(body) > 0 && body[0] == "" {
+		body = body[1:]
+	}
+	for len(body) > 0 && body[len(body)-1] == "" {
+		body = body[:len(body)-1]
+	}
+	if len(body) == 0 {
+		return subject
+	}
+	return subject + "\n\n" + strings.Join(body, "\n")
+}
+
+// CommitBoxHeight is the height of the Commit box. It stays a single line
+// until the input is focused, then grows with the message.
+func (s AppState) CommitBoxHeight() int {
+	if s.Focus != FocusCommand {
+		return 3
+	}
+	// Subject, ruler and at least one body row.
+	rows := max(3, len(s.CommitEditorLines())+1) + len(s.CommitAmendLines())
+	rows = min(rows, commitEditorMaxRows)
+	// Leave the panes below enough room to stay usable.
+	room := s.bodyHeight() - s.CommandLogPaneHeight() - 4 - 8 - 2
+	rows = min(rows, max(1, room))
+	return rows + 2
+}
+
+func (s *AppState) InsertCommandNewline() {
+	appendTextInput(&s.Command.Input, &s.Command.Cursor, &s.Command.SelectAll, "\n")
+}
+
+func (s *AppState) MoveCommandCursorUp() {
+	s.Command.SelectAll = false
+	s.Command.Cursor = moveCursorVertical(s.Command.Input, s.Command.Cursor, -1)
+}
+
+func (s *AppState) MoveCommandCursorDown() {
+	s.Command.SelectAll = false
+	s.Command.Cursor = moveCursorVertical(s.Command.Input, s.Command.Cursor, 1)
+}
+
+func (s *AppState) MoveCommandCursorLineStart() {
+	s.Command.SelectAll = false
+	_, col := s.CommitCursorLineCol()
+	s.Command.Cursor -= col
+}
+
+func (s *AppState) MoveCommandCursorLineEnd() {
+	s.Command.SelectAll = false
+	line, col := s.CommitCursorLineCol()
+	s.Command.Cursor += len([]rune(s.CommitEditorLines()[line])) - col
+}
+
+// WrapCommitBody breaks body lines longer than CommitBodyLimit at the last
+// space that fits. The subject is left alone. Only spaces are turned into
+// newlines, so the cursor keeps its position in the text.
+func (s *AppState) WrapCommitBody() {
+	s.Command.SelectAll = false
+	r := []rune(s.Command.Input)
+	lineStart := -1
+	for i := 0; i <= len(r); i++ {
+		if i < len(r) && r[i] != '\n' {
+			continue
+		}
+		if lineStart >= 0 {
+			wrapRunes(r[lineStart:i], CommitBodyLimit)
+		}
+		lineStart = i + 1
+	}
+	s.Command.Input = string(r)
+}
+
+// wrapRunes replaces spaces with newlines in place so that no line is longer
+// than width, except where a single word is longer.
+func wrapRunes(line []rune, width int) {
+	start := 0
+	lastSpace := -1
+	for i := 0; i < len(line); i++ {
+		if line[i] == ' ' {
+			lastSpace = i
+		}
+		if i-start < width || lastSpace < start {
+			continue
+		}
+		line[lastSpace] = '\n'
+		start = lastSpace + 1
+	}
+}
+
+func textLineCol(value string, cursor int) (line, col int) {
+	r := []rune(value)
+	cursor = max(0, min(cursor, len(r)))
+	for _, ch := range r[:cursor] {
+		if ch == '\n' {
+			line++
+			col = 0
+			continue
+		}
+		col++
+	}
+	return line, col
+}
+
+func moveCursorVertical(value string, cursor, delta int) int {
+	line, col := textLineCol(value, cursor)
+	lines := strings.Split(value, "\n")
+	target := line + delta
+	if target < 0 {
+		return 0
+	}
+	if target >= len(lines) {
+		return len([]rune(value))
+	}
+	pos := 0
+	for i := 0; i < target; i++ {
+		pos += len([]rune(lines[i])) + 1
+	}
+	return pos + min(col, len([]rune(lines[target])))
+}
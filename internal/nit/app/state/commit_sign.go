@@ -0,0 +1,45 @@
+package state
+
+import "strings"
+
+// SetSigningConfig applies commit.gpgsign and gpg.format. Signing follows the
+// new default unless a commit is being written.
+func (s *AppState) SetSigningConfig(sign bool, format string) {
+	s.Command.SignByDefault = sign
+	s.Command.SignFormat = format
+	if s.Focus != FocusCommand {
+		s.Command.CommitSign = sign
+	}
+}
+
+func (s *AppState) ToggleCommitSign() {
+	s.Command.CommitSign = !s.Command.CommitSign
+}
+
+// CommitSignLabel names the signing format for the Commit box title, or is
+// empty when the commit will not be signed.
+func (s AppState) CommitSignLabel() string {
+	if !s.Command.CommitSign {
+		return ""
+	}
+	switch s.Command.SignFormat {
+	case "", "openpgp":
+		return "signed (gpg)"
+	default:
+		return "signed (" + s.Command.SignFormat + ")"
+	}
+}
+
+// OpenSigningFailedDialog shows what the signing program printed when a
+// commit could not be signed.
+func (s *AppState) OpenSigningFailedDialog(program, output string) {
+	lines := []string{"git could not sign the commit with " + program + ":"}
+	for _, line := range strings.Split(output, "\n") {
+		lines = append(lines, "  "+line)
+	}
+	s.OpenDialog(DialogState{
+		Title:   "Commit signing failed",
+		Lines:   lines,
+		Options: []DialogOption{{Label: "OK"}},
+	})
+}
@@ -0,0 +1,338 @@
+package state
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/zGIKS/nit/internal/nit/app/actions"
+	"github.com/zGIKS/nit/internal/nit/git"
+)
+
+// confirmWord is what has to be typed to confirm the hardest operations when
+// the [confirm] type_yes switch is on.
+const confirmWord = "yes"
+
+// SetConfirmTypeYes makes discarding all changes, hard resets, force deletes
+// and remote branch deletes ask for "yes" to be typed.
+func (s *AppState) SetConfirmTypeYes(on bool) {
+	s.ConfirmTypeYes = on
+}
+
+// confirmDestructive holds back a result that runs a destructive operation
+// and opens the confirmation modal for it instead. Other results are returned
+// unchanged.
+func (s *AppState) confirmDestructive(res actions.ApplyResult) actions.ApplyResult {
+	for _, op := range res.Operations {
+		c, hard, ok := s.confirmation(op)
+		if !ok {
+			continue
+		}
+		c.Result = res
+		c.TypeYes = hard && s.ConfirmTypeYes
+		s.OpenConfirm(c)
+		return actions.ApplyResult{}
+	}
+	return res
+}
+
+// confirmation describes what op loses, and whether it is one of the hardest
+// operations to undo. ok is false for operations that lose nothing.
+func (s AppState) confirmation(op actions.Operation) (c ConfirmState, hard, ok bool) {
+	switch op.Kind {
+	case actions.OpDiscardAll:
+		c = ConfirmState{Title: "Discard all changes", Label: "Discard all changes"}
+		for _, e := range s.Changes.Entries {
+			c.Items = append(c.Items, changeItem(e))
+		}
+		if len(c.Items) == 0 {
+			c.Lines = []string{"There are no changes to discard."}
+		} else {
+			c.Lines = []string{fmt.Sprintf("Changes to %d file(s) are lost; untracked files are deleted:", len(c.Items))}
+		}
+		c.Note = "Ignored files are kept. Undo Last Discard brings the rest back."
+		return c, true, true
+	case actions.OpDiscardPatch:
+		c = ConfirmState{Title: "Discard changes in " + op.Path, Label: "Discard"}
+		c.Lines = []string{"These changes are discarded:"}
+		c.Note = "Undo Last Discard brings them back."
+
+		for _, line := range strings.Split(op.Patch, "\n") {
+			if (strings.HasPrefix(line, "+") && !strings.HasPrefix(line, "+++")) ||
+				(strings.HasPrefix(line, "-") && !strings.HasPrefix(line, "---")) {
+				c.Items = append(c.Items, line)
+			}
+		}
+		return c, false, true
+	case actions.OpDiscardFile:
+		c = ConfirmState{Title: "Discard " + op.Path, Label: "Discard"}
+		c.Lines = []string{"All changes to this file, staged or not, are discarded:"}
+		c.Items = []string{op.Path}
+		for _, e := range s.Changes.Entries {
+			if e.Path != op.Path {
+				continue
+			}
+			c.Items = []string{changeItem(e)}
+			if e.X == '?' {
+				c = ConfirmState{Title: "Delete " + op.Path, Label: "Delete", Lines: []string{"This untracked file is deleted:"}, Items: c.Items}
+			}
+		}
+		c.Note = "Undo Last Discard brings it back."
+		return c, false, true
+	case actions.OpUndoLastCommit:
+		c = ConfirmState{Title: "Undo last commit", Label: "Undo commit"}
+		c.Lines = []string{"This commit is removed from " + s.BranchName + "; its changes stay staged:"}
+		if head, found := s.headCommit(); found {
+			c.Items = []string{head.ShortHash + " " + head.Subject}
+		}
+		c.Note = "The reflog still has the commit."
+		return c, false, true
+	case actions.OpStashDrop:
+		c = ConfirmState{Title: "Drop " + op.Ref, Label: "Drop stash"}
+		c.Lines = []string{"This stash entry is deleted:"}
+		c.Items = []string{op.Ref}
+		for _, st := range s.Stash.Entries {
+			if st.Ref == op.Ref {
+				c.Items = []string{st.Ref + " " + st.Message}
+			}
+		}
+		c.Note = "A dropped stash can only be found again with git fsck."
+		return c, false, true
+	case actions.OpBranchDelete:
+		if !op.Force {
+			// git refuses a safe delete that would lose commits.
+			return c, false, false
+		}
+		c = ConfirmState{Title: "Force delete branch " + op.Ref, Label: "Force delete " + op.Ref, Branch: op.Ref}
+		return c, true, true
+	case actions.OpBranchDeleteRemote:
+		c = ConfirmState{Title: "Delete remote branch", Label: "Delete remote branch"}
+		upstream := ""
+		for _, b := range s.Branches.Entries {
+			if b.Kind == git.BranchLocal && b.Name == op.Ref {
+				upstream = b.Upstream
+			}
+		}
+		if upstream == "" {
+			c.Lines = []string{"The upstream branch of " + op.Ref + " is deleted from its remote, for everyone."}
+		} else {
+			c.Lines = []string{"This branch is deleted from its remote, for everyone:"}
+			c.Items = []string{upstream}
+		}
+		c.Note = "The local branch " + op.Ref + " is kept."
+		return c, true, true
+	case actions.OpTagDelete:
+		c = ConfirmState{Title: "Delete tag " + op.Ref, Label: "Delete " + op.Ref}
+		c.Lines = []string{"The tag is only deleted locally; remotes keep their copy."}
+		return c, false, true
+	case actions.OpMergeAbort, actions.OpAbortRebase, actions.OpCherryPickAbort, actions.OpRevertAbort:
+		return s.abortConfirmation(op.Kind), false, true
+	case actions.OpReset:
+		if op.ResetMode != string(git.ResetHard) {
+			return c, false, false
+		}
+		return s.resetConfirmation(), true, true
+	}
+	return c, false, false
+}
+
+func (s AppState) abortConfirmation(kind actions.OpKind) ConfirmState {
+	var c ConfirmState
+	switch kind {
+	case actions.OpMergeAbort:
+		c = ConfirmState{Title: "Abort merge", Lines: []string{"The merge is undone."}}
+	case actions.OpAbortRebase:
+		c = ConfirmState{Title: "Abort rebase", Lines: []string{s.BranchName + " goes back to where it was before the rebase."}}
+	case actions.OpCherryPickAbort:
+		c = ConfirmState{Title: "Abort cherry-pick", Lines: []string{"Commits picked so far are undone."}}
+	case actions.OpRevertAbort:
+		c = ConfirmState{Title: "Abort revert", Lines: []string{"Reverts committed so far are undone."}}
+	}
+	c.Label = c.Title
+	for _, e := range s.Changes.Entries {
+		if e.X != '?' {
+			c.Items = append(c.Items, changeItem(e))
+		}
+	}
+	if len(c.Items) == 0 {
+		c.Lines = append(c.Lines, "No uncommitted changes are lost.")
+	} else {
+		c.Lines = append(c.Lines, "Resolutions and changes to these files are lost:")
+	}
+	c.Note = "Untracked files are kept."
+	return c
+}
+
+// resetConfirmation lists what a hard reset to the commit of the reset modal
+// loses, from the preview loaded for it.
+func (s AppState) resetConfirmation() ConfirmState {
+	r := s.Reset
+	c := ConfirmState{
+		Title: fmt.Sprintf("Hard reset %s to %s", s.BranchName, r.ShortHash),
+		Label: "Reset --hard",
+	}
+	p := r.Preview
+	if p.Commits > 0 {
+		c.Lines = append(c.Lines, fmt.Sprintf("%d commit(s) after %s leave %s; the reflog still has them.", p.Commits, r.ShortHash, s.BranchName))
+	}
+	if len(p.Files) == 0 {
+		c.Lines = append(c.Lines, "No uncommitted changes are lost.")
+	} else {
+		c.Lines = append(c.Lines, fmt.Sprintf("Uncommitted changes to %d file(s) are lost:", len(p.Files)))
+	}
+	for _, f := range p.Files {
+		c.Items = append(c.Items, f.Status+" "+f.Path)
+	}
+	c.Note = "Untracked files are kept."
+	return c
+}
+
+func changeItem(e git.ChangeEntry) string {
+	return string([]byte{e.X, e.Y}) + " " + e.Path
+}
+
+// headCommit finds the checked out commit in the graph.
+func (s AppState) headCommit() (git.Commit, bool) {
+	for _, c := range s.Graph.Commits {
+		for _, ref := range c.Refs {
+			if ref == "HEAD" || strings.HasPrefix(ref, "HEAD -> ") {
+				return c, true
+			}
+		}
+	}
+	return git.Commit{}, false
+}
+
+func (s *AppState) OpenConfirm(c ConfirmState) {
+	s.CloseMenu()
+	s.CloseBranchCreate()
+	c.Open = true
+	c.Choice = 0
+	s.Confirm = c
+}
+
+func (s *AppState) CloseConfirm() {
+	s.Confirm = ConfirmState{}
+}
+
+func (s *AppState) MoveConfirmChoice(delta int) {
+	s.Confirm.Choice = ((s.Confirm.Choice+delta)%2 + 2) % 2
+}
+
+// AcceptConfirm runs the held back operation, or cancels it when Cancel is
+// chosen. It waits for the commits a force delete loses to be listed, and
+// for "yes" to be typed when that is required.
+func (s *AppState) AcceptConfirm() actions.ApplyResult {
+	c := s.Confirm
+	if !c.Open {
+		return actions.ApplyResult{}
+	}
+	if !c.TypeYes && c.Choice != 0 {
+		s.CloseConfirm()
+		return actions.ApplyResult{}
+	}
+	if c.Branch != "" && !c.Loaded {
+		return actions.ApplyResult{}
+	}
+	if c.TypeYes && !strings.EqualFold(strings.TrimSpace(c.Input), confirmWord) {
+		s.SetError(fmt.Sprintf("type %q to confirm", confirmWord))
+		return actions.ApplyResult{}
+	}
+	s.CloseConfirm()
+	s.SetError("")
+	return c.Result
+}
+
+// ConfirmUnmergedTarget reports the branch whose unmerged commits still have
+// to be loaded.
+func (s AppState) ConfirmUnmergedTarget() (string, bool) {
+	c := s.Confirm
+	return c.Branch, c.Open && c.Branch != "" && !c.Loaded && !c.Loading
+}
+
+func (s *AppState) BeginConfirmLoad() {
+	s.Confirm.Loading = true
+}
+
+func (s *AppState) SetConfirmUnmerged(branch string, commits []git.Commit) {
+	if !s.Confirm.Open || s.Confirm.Branch != branch {
+		return
+	}
+	c := &s.Confirm
+	c.Loading = false
+	c.Loaded = true
+	c.Items = nil
+	for _, commit := range commits {
+		c.Items = append(c.Items, commit.ShortHash+" "+commit.Subject)
+	}
+	if len(commits) == 0 {
+		c.Lines = []string{"Every commit on " + branch + " is on another branch or tag; nothing is lost."}
+		return
+	}
+	c.Lines = []string{fmt.Sprintf("%d commit(s) that only %s has are lost:", len(commits), branch)}
+}
+
+// ConfirmLines are the rows explaining the operation.
+func (s AppState) ConfirmLines() []string {
+	c := s.Confirm
+	if c.Branch != "" && !c.Loaded {
+		return []string{"Checking for commits not merged anywhere..."}
+	}
+	return c.Lines
+}
+
+// ConfirmItems are the items shown, cut short to fit the screen.
+func (s AppState) ConfirmItems() []string {
+	items := s.Confirm.Items
+	if s.Confirm.Branch != "" && !s.Confirm.Loaded {
+		return nil
+	}
+	room := max(2, s.confirmMaxHeight()-s.confirmFixedRows())
+	if len(items) <= room {
+		return items
+	}
+	shown := append([]string{}, items[:room-1]...)
+	return append(shown, fmt.Sprintf("... and %d more", len(items)-(room-1)))
+}
+
+func (s *AppState) ConfirmAppendText(text string) {
+	appendTextInput(&s.Confirm.Input, &s.Confirm.Cursor, &s.Confirm.SelectAll, text)
+}
+
+func (s *AppState) ConfirmBackspace() {
+	backspaceTextInput(&s.Confirm.Input, &s.Confirm.Cursor, &s.Confirm.SelectAll)
+}
+
+func (s *AppState) ConfirmDelete() {
+	deleteTextInput(&s.Confirm.Input, &s.Confirm.Cursor, &s.Confirm.SelectAll)
+}
+
+func (s *AppState) ConfirmCursorLeft() {
+	moveTextInputCursorLeft(&s.Confirm.Cursor, &s.Confirm.SelectAll)
+}
+
+func (s *AppState) ConfirmCursorRight() {
+	moveTextInputCursorRight(s.Confirm.Input, &s.Confirm.Cursor, &s.Confirm.SelectAll)
+}
+
+func (s *AppState) ConfirmCursorHome() {
+	moveTextInputCursorHome(&s.Confirm.Cursor, &s.Confirm.SelectAll)
+}
+
+func (s *AppState) ConfirmCursorEnd() {
+	moveTextInputCursorEnd(s.Confirm.Input, &s.Confirm.Cursor, &s.Confirm.SelectAll)
+}
+
+func (s *AppState) ConfirmSelectAllText() {
+	selectAllTextInput(s.Confirm.Input, &s.Confirm.Cursor, &s.Confirm.SelectAll)
+}
+
+func (s AppState) SelectedConfirmText() string {
+	if s.Confirm.SelectAll {
+		return s.Confirm.Input
+	}
+	return ""
+}
+
+func (s *AppState) DeleteConfirmSelection() {
+	clearSelectedText(&s.Confirm.Input, &s.Confirm.Cursor, &s.Confirm.SelectAll)
+}
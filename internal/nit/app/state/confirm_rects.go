@@ -0,0 +1,48 @@
+package state
+
+func (s AppState) confirmMaxHeight() int {
+	return max(12, s.Viewport.Height) - 2
+}
+
+// confirmFixedRows counts the rows of the confirmation modal besides its
+// items: border, title, separator, lines, note, blank, the options or the
+// "yes" input and its hint, border.
+func (s AppState) confirmFixedRows() int {
+	rows := 3 + len(s.ConfirmLines()) + 1 + 2 + 1
+	if s.Confirm.Note != "" {
+		rows++
+	}
+	return rows
+}
+
+func (s AppState) ConfirmPanelRect() (x, y, w, h int) {
+	totalW := max(40, s.Viewport.Width)
+	totalH := max(12, s.Viewport.Height)
+	w = min(80, totalW)
+	h = min(s.confirmFixedRows()+len(s.ConfirmItems()), totalH)
+	x = max(0, (totalW-w)/2)
+	y = max(0, (totalH-h)/2)
+	return x, y, w, h
+}
+
+// ConfirmClick picks the clicked option, or cancels on a click outside of the
+// modal.
+func (s *AppState) ConfirmClick(x, y int) (chosen bool, consumed bool) {
+	if !s.Confirm.Open {
+		return false, false
+	}
+	px, py, pw, ph := s.ConfirmPanelRect()
+	if x < px || x >= px+pw || y < py || y >= py+ph {
+		s.CloseConfirm()
+		return false, true
+	}
+	if s.Confirm.TypeYes {
+		return false, true
+	}
+	idx := y - (py + ph - 3)
+	if idx >= 0 && idx < 2 {
+		s.Confirm.Choice = idx
+		return true, true
+	}
+	return false, true
+}
@@ -10,16 +10,21 @@ func (s *AppState) selectedChange() (git.ChangeEntry, Section, bool) {
 	if !row.Selectable {
 		return git.ChangeEntry{}, "", false
 	}
-	if row.Section == SectionStaged {
-		if row.EntryIndex < 0 || row.EntryIndex >= len(s.Changes.Staged) {
-			return git.ChangeEntry{}, "", false
-		}
-		return s.Changes.Staged[row.EntryIndex], row.Section, true
-	}
-	if row.EntryIndex < 0 || row.EntryIndex >= len(s.Changes.Unstaged) {
+	entries := s.sectionEntries(row.Section)
+	if row.EntryIndex < 0 || row.EntryIndex >= len(entries) {
 		return git.ChangeEntry{}, "", false
 	}
-	return s.Changes.Unstaged[row.EntryIndex], row.Section, true
+	return entries[row.EntryIndex], row.Section, true
+}
+
+func (s *AppState) sectionEntries(section Section) []git.ChangeEntry {
+	switch section {
+	case SectionConflicted:
+		return s.Changes.Conflicted
+	case SectionStaged:
+		return s.Changes.Staged
+	}
+	return s.Changes.Unstaged
 }
 
 func (s *AppState) selectedPath() (string, Section, bool) {
@@ -31,18 +36,12 @@ func (s *AppState) selectedPath() (string, Section, bool) {
 }
 
 func (s *AppState) moveCursorToPath(path string, section Section) bool {
+	entries := s.sectionEntries(section)
 	for i, row := range s.Changes.Rows {
 		if !row.Selectable || row.Section != section {
 			continue
 		}
-		if section == SectionStaged {
-			if row.EntryIndex >= 0 && row.EntryIndex < len(s.Changes.Staged) && s.Changes.Staged[row.EntryIndex].Path == path {
-				s.Changes.Cursor = i
-				return true
-			}
-			continue
-		}
-		if row.EntryIndex >= 0 && row.EntryIndex < len(s.Changes.Unstaged) && s.Changes.Unstaged[row.EntryIndex].Path == path {
+		if row.EntryIndex >= 0 && row.EntryIndex < len(entries) && entries[row.EntryIndex].Path == path {
 			s.Changes.Cursor = i
 			return true
 		}
@@ -0,0 +1,108 @@
+package state
+
+import (
+	"fmt"
+
+	"github.com/zGIKS/nit/internal/nit/app/actions"
+)
+
+func (s *AppState) OpenDialog(d DialogState) {
+	s.CloseMenu()
+	s.CloseBranchCreate()
+	d.Open = true
+	d.Cursor = 0
+	d.Offset = 0
+	s.Dialog = d
+}
+
+func (s *AppState) CloseDialog() {
+	s.Dialog = DialogState{}
+}
+
+func (s *AppState) MoveDialogCursor(delta int) {
+	n := len(s.Dialog.Options)
+	if n == 0 {
+		return
+	}
+	s.Dialog.Cursor = ((s.Dialog.Cursor+delta)%n + n) % n
+	s.scrollDialogToCursor()
+}
+
+// ScrollDialog scrolls a dialog with more options than fit on screen,
+// keeping the cursor on a visible option.
+func (s *AppState) ScrollDialog(delta int) bool {
+	if !s.Dialog.Open {
+		return false
+	}
+	visible := s.dialogVisibleOptions()
+	s.Dialog.Offset = max(0, min(len(s.Dialog.Options)-visible, s.Dialog.Offset+delta))
+	s.Dialog.Cursor = max(s.Dialog.Offset, min(s.Dialog.Offset+visible-1, s.Dialog.Cursor))
+	return true
+}
+
+func (s *AppState) scrollDialogToCursor() {
+	visible := s.dialogVisibleOptions()
+	if s.Dialog.Cursor < s.Dialog.Offset {
+		s.Dialog.Offset = s.Dialog.Cursor
+	}
+	if s.Dialog.Cursor >= s.Dialog.Offset+visible {
+		s.Dialog.Offset = s.Dialog.Cursor - visible + 1
+	}
+}
+
+// DialogOptionsWindow is the range of options shown.
+func (s AppState) DialogOptionsWindow() (start, end int) {
+	start = s.Dialog.Offset
+	return start, min(len(s.Dialog.Options), start+s.dialogVisibleOptions())
+}
+
+// ChooseDialogOption closes the dialog and returns the result of the option
+// under the cursor, opening its follow-up prompt or commit details if it has
+// them, or applying its action. A destructive result still has to be
+// confirmed.
+func (s *AppState) ChooseDialogOption() actions.ApplyResult {
+	idx := s.Dialog.Cursor
+	opts := s.Dialog.Options
+	s.CloseDialog()
+	if idx < 0 || idx >= len(opts) {
+		return actions.ApplyResult{}
+	}
+	if opts[idx].Prompt.Kind != "" {
+		s.OpenPrompt(opts[idx].Prompt)
+	}
+	if c := opts[idx].Commit; c.Hash != "" {
+		s.OpenCommitDetail(c.Hash, "Commit "+c.ShortHash)
+	}
+	if opts[idx].Action != actions.ActionNone {
+		return s.Apply(opts[idx].Action)
+	}
+	return s.confirmDestructive(opts[idx].Result)
+}
+
+// OpenSwitchBlockedDialog asks what to do with local changes that keep a
+// branch switch from going through.
+func (s *AppState) OpenSwitchBlockedDialog(branch string, files []string) {
+	lines := []string{fmt.Sprintf("Local changes would be overwritten by switching to %s:", branch)}
+	const maxFiles = 5
+	for i, f := range files {
+		if i == maxFiles {
+			lines = append(lines, fmt.Sprintf("  ... and %d more", len(files)-maxFiles))
+			break
+		}
+		lines = append(lines, "  "+f)
+	}
+	switched := actions.ApplyResult{RefreshChanges: true, RefreshGraph: true, RefreshRepoSummary: true}
+	stash := switched
+	stash.Operations = []actions.Operation{{Kind: actions.OpStashAndSwitch, Ref: branch}}
+	carry := switched
+	carry.Operations = []actions.Operation{{Kind: actions.OpSwitchBranchCarry, Ref: branch}}
+	s.OpenDialog(DialogState{
+		Title: "Switch to " + branch,
+		Lines: lines,
+		Options: []DialogOption{
+			{Label: "Stash changes and switch (restored when you come back)", Result: stash},
+			{Label: "Carry changes over to " + branch, Result: carry},
+			{Label: "Cancel"},
+		},
+	})
+}
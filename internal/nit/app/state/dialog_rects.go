@@ -0,0 +1,44 @@
+package state
+
+func (s AppState) DialogPanelRect() (x, y, w, h int) {
+	totalW := max(40, s.Viewport.Width)
+	totalH := max(12, s.Viewport.Height)
+	w = min(72, totalW)
+	// border, title, separator, lines, blank, options, border
+	h = 3 + len(s.Dialog.Lines) + 1 + len(s.Dialog.Options) + 1
+	h = min(h, totalH)
+	x = max(0, (totalW-w)/2)
+	y = max(0, (totalH-h)/2)
+	return x, y, w, h
+}
+
+// dialogVisibleOptions is how many options fit in the dialog.
+func (s AppState) dialogVisibleOptions() int {
+	_, _, _, h := s.DialogPanelRect()
+	return max(1, h-(3+len(s.Dialog.Lines)+1+1))
+}
+
+func (s AppState) dialogOptionsTop() int {
+	_, py, _, _ := s.DialogPanelRect()
+	return py + 3 + len(s.Dialog.Lines) + 1
+}
+
+// DialogClick picks the clicked option, or cancels the dialog on a click
+// outside of it.
+func (s *AppState) DialogClick(x, y int) (chosen bool, consumed bool) {
+	if !s.Dialog.Open {
+		return false, false
+	}
+	px, py, pw, ph := s.DialogPanelRect()
+	if x < px || x >= px+pw || y < py || y >= py+ph {
+		s.CloseDialog()
+		return false, true
+	}
+	start, end := s.DialogOptionsWindow()
+	idx := start + y - s.dialogOptionsTop()
+	if idx >= start && idx < end {
+		s.Dialog.Cursor = idx
+		return true, true
+	}
+	return false, true
+}
@@ -0,0 +1,193 @@
+package state
+
+import (
+	"github.com/zGIKS/nit/internal/nit/app/actions"
+	"github.com/zGIKS/nit/internal/nit/git"
+)
+
+// DiffTarget reports the file the diff pane should follow: the change under
+// the Changes cursor, staged or not depending on its section.
+func (s AppState) DiffTarget() (path string, staged bool, ok bool) {
+	e, sec, ok := s.selectedChange()
+	if !ok {
+		return "", false, false
+	}
+	return e.Path, sec == SectionStaged, true
+}
+
+func (s AppState) DiffIsFor(path string, staged bool) bool {
+	return s.Diff.Path == path && s.Diff.Staged == staged
+}
+
+// BeginDiffLoad points the diff pane at a new target. Scroll position is kept
+// when the target does not change so background refreshes do not jump.
+func (s *AppState) BeginDiffLoad(path string, staged bool) {
+	if !s.DiffIsFor(path, staged) {
+		s.Diff = DiffState{Path: path, Staged: staged}
+	}
+}
+
+func (s *AppState) SetDiff(d git.FileDiff) {
+	if !s.DiffIsFor(d.Path, d.Staged) {
+		return
+	}
+	lines := d.Lines()
+	if !sameDiffLines(s.Diff.Lines, lines) {
+		// Marks point at line positions and are meaningless once the
+		// content moves.
+		s.Diff.Marked = nil
+	}
+	s.Diff.File = d
+	s.Diff.Lines = lines
+	s.Diff.Loaded = true
+	clampScrollView(len(s.Diff.Lines), &s.Diff.Cursor, &s.Diff.Offset, s.diffPageSize())
+}
+
+func (s *AppState) ClearDiff() {
+	s.Diff = DiffState{}
+	if s.Focus == FocusDiff {
+		s.Focus = FocusChanges
+	}
+}
+
+// ToggleDiffLineMark marks or unmarks the changed line under the diff cursor
+// for line-level staging. On a hunk header it toggles the whole hunk.
+func (s *AppState) ToggleDiffLineMark() {
+	if s.Diff.Cursor < 0 || s.Diff.Cursor >= len(s.Diff.Lines) {
+		return
+	}
+	cur := s.Diff.Lines[s.Diff.Cursor]
+	if cur.Hunk < 0 {
+		return
+	}
+	if s.Diff.Marked == nil {
+		s.Diff.Marked = map[int]bool{}
+	}
+	if cur.Kind == git.DiffLineHunk {
+		idx := s.hunkChangedLines(cur.Hunk)
+		all := len(idx) > 0
+		for _, i := range idx {
+			all = all && s.Diff.Marked[i]
+		}
+		for _, i := range idx {
+			if all {
+				delete(s.Diff.Marked, i)
+			} else {
+				s.Diff.Marked[i] = true
+			}
+		}
+		return
+	}
+	if !isChangedDiffLine(cur) {
+		return
+	}
+	if s.Diff.Marked[s.Diff.Cursor] {
+		delete(s.Diff.Marked, s.Diff.Cursor)
+	} else {
+		s.Diff.Marked[s.Diff.Cursor] = true
+	}
+}
+
+func (s AppState) hunkChangedLines(hunk int) []int {
+	var idx []int
+	for i, l := range s.Diff.Lines {
+		if l.Hunk == hunk && isChangedDiffLine(l) {
+			idx = append(idx, i)
+		}
+	}
+	return idx
+}
+
+// diffSelectionPatch builds the patch for the marked lines, or for the hunk
+// under the cursor when nothing is marked.
+func (s AppState) diffSelectionPatch(reverse bool) (string, bool) {
+	if !s.Diff.Loaded || len(s.Diff.Lines) == 0 {
+		return "", false
+	}
+	if len(s.Diff.Marked) > 0 {
+		marked := make(map[[2]int]bool, len(s.Diff.Marked))
+		for i := range s.Diff.Marked {
+			if i >= 0 && i < len(s.Diff.Lines) {
+				l := s.Diff.Lines[i]
+				marked[[2]int{l.Hunk, l.Line}] = true
+			}
+		}
+		patch := s.Diff.File.Patch(func(hunk, line int) bool { return marked[[2]int{hunk, line}] }, reverse)
+		return patch, patch != ""
+	}
+	if s.Diff.Cursor < 0 || s.Diff.Cursor >= len(s.Diff.Lines) {
+		return "", false
+	}
+	target := s.Diff.Lines[s.Diff.Cursor].Hunk
+	if target < 0 {
+		return "", false
+	}
+	patch := s.Diff.File.Patch(func(hunk, _ int) bool { return hunk == target }, reverse)
+	return patch, patch != ""
+}
+
+// applyDiffSelection stages (or unstages, for a staged diff) the current
+// diff selection.
+func (s *AppState) applyDiffSelection() []actions.Operation {
+	if s.diffIsConflicted() {
+		return nil
+	}
+	kind := actions.OpStagePatch
+	if s.Diff.Staged {
+		kind = actions.OpUnstagePatch
+	}
+	patch, ok := s.diffSelectionPatch(s.Diff.Staged)
+	if !ok {
+		s.SetError("move the cursor onto a hunk or mark lines with " + s.Keys.DisplayBinding(actions.ActionToggleLine))
+		return nil
+	}
+	if s.Diff.Staged {
+		s.Changes.StickySection = SectionStaged
+	} else {
+		s.Changes.StickySection = SectionUnstaged
+	}
+	return []actions.Operation{{Kind: kind, Path: s.Diff.Path, Patch: patch}}
+}
+
+func (s *AppState) discardDiffSelection() []actions.Operation {
+	if s.diffIsConflicted() {
+		return nil
+	}
+	if s.Diff.Staged {
+		s.SetError("unstage the change before discarding it")
+		return nil
+	}
+	patch, ok := s.diffSelectionPatch(true)
+	if !ok {
+		s.SetError("move the cursor onto a hunk or mark lines with " + s.Keys.DisplayBinding(actions.ActionToggleLine))
+		return nil
+	}
+	return []actions.Operation{{Kind: actions.OpDiscardPatch, Path: s.Diff.Path, Patch: patch}}
+}
+
+// diffIsConflicted refuses patches against a conflicted file, which git
+// cannot apply until the conflict is resolved.
+func (s *AppState) diffIsConflicted() bool {
+	e, sec, ok := s.selectedChange()
+	if !ok || sec != SectionConflicted {
+		return false
+	}
+	s.SetError("resolve the conflicts in " + e.Path + " first")
+	return true
+}
+
+func isChangedDiffLine(l git.DiffLine) bool {
+	return l.Kind == git.DiffLineAdded || l.Kind == git.DiffLineRemoved
+}
+
+func sameDiffLines(a, b []git.DiffLine) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Text != b[i].Text {
+			return false
+		}
+	}
+	return true
+}
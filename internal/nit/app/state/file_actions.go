@@ -0,0 +1,183 @@
+package state
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/zGIKS/nit/internal/nit/app/actions"
+	"github.com/zGIKS/nit/internal/nit/git"
+)
+
+// selectedFileForAction returns the change under the Changes cursor, or the
+// file shown in the diff pane when that has focus.
+func (s *AppState) selectedFileForAction() (git.ChangeEntry, bool) {
+	switch s.Focus {
+	case FocusChanges:
+		if entry, _, ok := s.selectedChange(); ok {
+			return entry, true
+		}
+	case FocusDiff:
+		for _, e := range s.Changes.Entries {
+			if e.Path == s.Diff.Path {
+				return e, true
+			}
+		}
+	}
+	s.SetError("select a file in Changes")
+	return git.ChangeEntry{}, false
+}
+
+// OpenFileMenu offers the actions on the selected file.
+func (s *AppState) OpenFileMenu() {
+	entry, ok := s.selectedFileForAction()
+	if !ok {
+		return
+	}
+	discard := "Discard changes"
+	if entry.X == '?' {
+		discard = "Delete file"
+	}
+	opts := []DialogOption{{Label: s.withKeyHint(discard, actions.ActionDiscardSelection), Result: discardFileResult(entry)}}
+	if entry.X == '?' {
+		opts = append(opts, DialogOption{Label: "Add to .gitignore", Result: actions.ApplyResult{
+			Operations:     []actions.Operation{{Kind: actions.OpIgnorePath, Path: entry.Path}},
+			RefreshChanges: true,
+		}})
+	}
+	opts = append(opts,
+		DialogOption{Label: s.withKeyHint("Open in editor", actions.ActionOpenInEditor), Action: actions.ActionOpenInEditor},
+		DialogOption{Label: s.withKeyHint("Copy path", actions.ActionCopyPath), Action: actions.ActionCopyPath},
+		DialogOption{Label: s.withKeyHint("Show file history", actions.ActionFileHistory), Action: actions.ActionFileHistory},
+		DialogOption{Label: "Cancel"},
+	)
+	s.OpenDialog(DialogState{
+		Title:   entry.Path,
+		Lines:   []string{changeItem(entry)},
+		Options: opts,
+	})
+}
+
+// withKeyHint appends the key bound to action, if any, to a menu label.
+func (s AppState) withKeyHint(label string, action actions.Action) string {
+	if key := s.Keys.DisplayBinding(action); key != "" {
+		return fmt.Sprintf("%s (%s)", label, key)
+	}
+	return label
+}
+
+// discardFileResult throws away every change to entry; renamed files get
+// their old path back too.
+func discardFileResult(entry git.ChangeEntry) actions.ApplyResult {
+	return actions.ApplyResult{
+		Operations:     []actions.Operation{{Kind: actions.OpDiscardFile, Path: entry.Path, Target: entry.OrigPath()}},
+		RefreshChanges: true,
+	}
+}
+
+func (s *AppState) discardSelectedFile() actions.ApplyResult {
+	entry, ok := s.selectedFileForAction()
+	if !ok {
+		return actions.ApplyResult{}
+	}
+	return discardFileResult(entry)
+}
+
+// RequestOpenInEditor asks for the selected file to be opened in the editor,
+// at its first change when its diff is shown.
+func (s *AppState) RequestOpenInEditor() {
+	entry, ok := s.selectedFileForAction()
+	if !ok {
+		return
+	}
+	line := 0
+	if s.Diff.Loaded && s.Diff.Path == entry.Path {
+		line = s.Diff.File.FirstChangedLine()
+	}
+	s.Editor = EditorRequest{Path: entry.Path, Line: line}
+}
+
+// RequestCommitMessageEditor asks for the commit message to be written in
+// the editor, starting from what the commit input holds.
+func (s *AppState) RequestCommitMessageEditor() {
+	s.Editor = EditorRequest{CommitMessage: true, Message: s.Command.Input}
+}
+
+// SetCommitMessageFromEditor puts the message written in the editor into the
+// commit input, unless it is empty.
+func (s *AppState) SetCommitMessageFromEditor(message string) {
+	if strings.TrimSpace(message) == "" {
+		return
+	}
+	s.Command.Input = message
+	s.Command.Cursor = len([]rune(message))
+	s.Command.SelectAll = false
+}
+
+// TakeEditorRequest returns the pending editor request and clears it.
+func (s *AppState) TakeEditorRequest() (EditorRequest, bool) {
+	r := s.Editor
+	s.Editor = EditorRequest{}
+	return r, r.Path != "" || r.CommitMessage
+}
+
+// RequestCopyPath asks for the path of the selected file to be copied to the
+// clipboard.
+func (s *AppState) RequestCopyPath() {
+	if entry, ok := s.selectedFileForAction(); ok {
+		s.CopyRequest = entry.Path
+	}
+}
+
+// TakeCopyRequest returns the text waiting to be copied and clears it.
+func (s *AppState) TakeCopyRequest() (string, bool) {
+	text := s.CopyRequest
+	s.CopyRequest = ""
+	return text, text != ""
+}
+
+// OpenFileHistory asks for the commits that changed the selected file, which
+// open in a dialog once loaded.
+func (s *AppState) OpenFileHistory() {
+	if entry, ok := s.selectedFileForAction(); ok {
+		s.FileHistory = FileHistoryState{Path: entry.Path}
+	}
+}
+
+// FileHistoryTarget reports the file whose history still has to be loaded.
+func (s AppState) FileHistoryTarget() (string, bool) {
+	h := s.FileHistory
+	return h.Path, h.Path != "" && !h.Loading
+}
+
+func (s *AppState) BeginFileHistoryLoad() {
+	s.FileHistory.Loading = true
+}
+
+func (s *AppState) FileHistoryFailed(err error) {
+	s.FileHistory = FileHistoryState{}
+	s.SetError(err.Error())
+}
+
+// SetFileHistory offers the commits that changed path in a dialog where
+// choosing one opens its details.
+func (s *AppState) SetFileHistory(path string, commits []git.Commit) {
+	if s.FileHistory.Path != path {
+		return
+	}
+	s.FileHistory = FileHistoryState{}
+	if len(commits) == 0 {
+		s.SetError(path + " has no commits yet")
+		return
+	}
+	opts := make([]DialogOption, 0, len(commits)+1)
+	for _, c := range commits {
+		label := fmt.Sprintf("%s %s %s", c.ShortHash, c.Date.Local().Format("2006-01-02"), c.Subject)
+		opts = append(opts, DialogOption{Label: label, Commit: c})
+	}
+	opts = append(opts, DialogOption{Label: "Cancel"})
+	s.OpenDialog(DialogState{
+		Title:   "History of " + path,
+		Lines:   []string{fmt.Sprintf("%d commit(s), newest first; Enter shows one.", len(commits))},
+		Options: opts,
+	})
+}
@@ -0,0 +1,133 @@
+package state
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/zGIKS/nit/internal/nit/app/actions"
+	"github.com/zGIKS/nit/internal/nit/git"
+)
+
+// inProgressKind is the submenu listing the actions of the operation git has
+// left in progress. It is shown first in the main menu while there is one.
+const inProgressKind = "progress"
+
+// SetRepoState records the operation in progress. The main menu gains or
+// loses its entry when it changes, so an open menu is closed.
+func (s *AppState) SetRepoState(state git.RepoState, step, steps int) {
+	if state != s.RepoState && s.MenuOpen {
+		s.CloseMenu()
+	}
+	s.RepoState = state
+	s.RepoStep = step
+	s.RepoSteps = steps
+}
+
+// RepoStateBanner is the top bar banner for the operation in progress, e.g.
+// "REBASING 2/5 · continue, skip or abort from the menu", or "".
+func (s AppState) RepoStateBanner() string {
+	var name, hint string
+	switch s.RepoState {
+	case git.RepoMerging:
+		name, hint = "MERGING", "continue or abort"
+	case git.RepoRebasing:
+		name, hint = "REBASING", "continue, skip or abort"
+		if s.RepoSteps > 0 {
+			name += fmt.Sprintf(" %d/%d", s.RepoStep, s.RepoSteps)
+		}
+	case git.RepoCherryPicking:
+		name, hint = "CHERRY-PICKING", "continue, skip or abort"
+	case git.RepoReverting:
+		name, hint = "REVERTING", "continue, skip or abort"
+	case git.RepoBisecting:
+		name, hint = "BISECTING", "skip or reset"
+	default:
+		return ""
+	}
+	menu := strings.TrimSpace(s.MenuLabel)
+	if menu == "" {
+		menu = "..."
+	}
+	return name + " · " + hint + " from " + menu
+}
+
+func (s AppState) inProgressMenuLabel() string {
+	switch s.RepoState {
+	case git.RepoMerging:
+		return "Merge in Progress"
+	case git.RepoRebasing:
+		return "Rebase in Progress"
+	case git.RepoCherryPicking:
+		return "Cherry-pick in Progress"
+	case git.RepoReverting:
+		return "Revert in Progress"
+	case git.RepoBisecting:
+		return "Bisect in Progress"
+	}
+	return ""
+}
+
+func (s AppState) inProgressMenuItems() []DropdownMenuItem {
+	switch s.RepoState {
+	case git.RepoMerging:
+		return []DropdownMenuItem{{Label: "Continue Merge"}, {Label: "Abort Merge"}}
+	case git.RepoRebasing:
+		return []DropdownMenuItem{{Label: "Continue Rebase"}, {Label: "Skip Commit"}, {Label: "Abort Rebase"}}
+	case git.RepoCherryPicking:
+		return []DropdownMenuItem{{Label: "Continue Cherry-pick"}, {Label: "Skip Commit"}, {Label: "Abort Cherry-pick"}}
+	case git.RepoReverting:
+		return []DropdownMenuItem{{Label: "Continue Revert"}, {Label: "Skip Commit"}, {Label: "Abort Revert"}}
+	case git.RepoBisecting:
+		return []DropdownMenuItem{{Label: "Skip Commit"}, {Label: "Reset Bisect"}}
+	}
+	return nil
+}
+
+// inProgressMenuAction maps an item of the in-progress submenu to the generic
+// action that runs it.
+func inProgressMenuAction(label string) actions.Action {
+	switch {
+	case strings.HasPrefix(label, "Continue "):
+		return actions.ActionContinueOperation
+	case strings.HasPrefix(label, "Skip "):
+		return actions.ActionSkipOperation
+	case strings.HasPrefix(label, "Abort "), strings.HasPrefix(label, "Reset "):
+		return actions.ActionAbortOperation
+	}
+	return actions.ActionNone
+}
+
+// inProgressOps holds the operations that continue, skip and abort each
+// state; -1 marks one git does not offer.
+var inProgressOps = map[git.RepoState][3]actions.OpKind{
+	git.RepoMerging:       {actions.OpMergeContinue, -1, actions.OpMergeAbort},
+	git.RepoRebasing:      {actions.OpRebaseContinue, actions.OpRebaseSkip, actions.OpAbortRebase},
+	git.RepoCherryPicking: {actions.OpCherryPickContinue, actions.OpCherryPickSkip, actions.OpCherryPickAbort},
+	git.RepoReverting:     {actions.OpRevertContinue, actions.OpRevertSkip, actions.OpRevertAbort},
+	git.RepoBisecting:     {-1, actions.OpBisectSkip, actions.OpBisectReset},
+}
+
+// inProgressOperation returns the operation that continues, skips or aborts
+// (action) whatever git has in progress.
+func (s *AppState) inProgressOperation(action actions.Action) []actions.Operation {
+	ops, ok := inProgressOps[s.RepoState]
+	if !ok {
+		s.SetError("no merge, rebase, cherry-pick, revert or bisect in progress")
+		return nil
+	}
+	var idx int
+	var verb string
+	switch action {
+	case actions.ActionContinueOperation:
+		idx, verb = 0, "continued"
+	case actions.ActionSkipOperation:
+		idx, verb = 1, "skipped"
+	default:
+		idx, verb = 2, "aborted"
+	}
+	if ops[idx] < 0 {
+		s.SetError(fmt.Sprintf("a %s cannot be %s", s.RepoState, verb))
+		return nil
+	}
+	return []actions.Operation{{Kind: ops[idx]}}
+}
@@ -1,20 +1,40 @@
 package state
 
-func (s AppState) GraphBranchesPaneWidths() (graphW, branchW int) {
+func (s AppState) GraphRowPaneWidths() (graphW, branchW, stashW int) {
 	totalW := max(40, s.Viewport.Width)
-	branchW = max(24, totalW/3)
-	if branchW > totalW-20 {
-		branchW = max(18, totalW-20)
-	}
-	graphW = totalW - branchW - 1
+	branchW = max(20, totalW/5)
+	stashW = branchW
+	graphW = totalW - branchW - stashW - 2
 	if graphW < 20 {
 		graphW = 20
-		branchW = max(18, totalW-graphW-1)
+		branchW = max(10, (totalW-graphW-2)/2)
+		stashW = max(10, totalW-graphW-branchW-2)
+	}
+	return graphW, branchW, stashW
+}
+
+func (s AppState) ChangesDiffPaneWidths() (listW, diffW int) {
+	totalW := max(40, s.Viewport.Width)
+	listW = max(24, (totalW*2)/5)
+	if listW > totalW-20 {
+		listW = max(18, totalW-20)
 	}
-	return graphW, branchW
+	diffW = totalW - listW - 1
+	if diffW < 20 {
+		diffW = 20
+		listW = max(18, totalW-diffW-1)
+	}
+	return listW, diffW
 }
 
 func (s *AppState) Clamp() {
+	if s.CommitDetail.Open {
+		s.clampCommitDetail()
+	}
+	if s.Rebase.Open {
+		s.clampRebase()
+	}
+
 	if s.Focus == FocusGraph {
 		clampScrollView(len(s.Graph.Lines), &s.Graph.Cursor, &s.Graph.Offset, s.graphPageSize())
 		return
@@ -25,6 +45,16 @@ func (s *AppState) Clamp() {
 		return
 	}
 
+	if s.Focus == FocusDiff {
+		clampScrollView(len(s.Diff.Lines), &s.Diff.Cursor, &s.Diff.Offset, s.diffPageSize())
+		return
+	}
+
+	if s.Focus == FocusStash {
+		clampScrollView(len(s.Stash.Lines), &s.Stash.Cursor, &s.Stash.Offset, s.stashPageSize())
+		return
+	}
+
 	if s.Focus == FocusCommandLog {
 		clampScrollView(len(s.CommandLog), &s.CommandLogView.Cursor, &s.CommandLogView.Offset, s.commandLogPageSize())
 		return
@@ -63,7 +93,7 @@ func (s AppState) ChangesPaneHeight() int {
 }
 
 func (s AppState) CommandPaneHeight() int {
-	return 7
+	return 4 + s.CommitBoxHeight()
 }
 
 func (s AppState) CommandLogPaneHeight() int {
@@ -86,6 +116,10 @@ func (s AppState) changesPageSize() int {
 	return h
 }
 
+func (s AppState) diffPageSize() int {
+	return s.changesPageSize()
+}
+
 func (s AppState) commandLogPageSize() int {
 	h := s.CommandLogPaneHeight() - 2
 	if h < 1 {
@@ -102,6 +136,10 @@ func (s AppState) branchesPageSize() int {
 	return h
 }
 
+func (s AppState) stashPageSize() int {
+	return s.branchesPageSize()
+}
+
 func clampScrollView(total int, cursor, offset *int, page int) {
 	if cursor == nil || offset == nil {
 		return
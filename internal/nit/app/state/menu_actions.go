@@ -34,13 +34,14 @@ func (s *AppState) openSubmenu(kind string) {
 }
 
 func (s *AppState) OpenSubmenuForMenuIndex(idx int) bool {
-	if idx < 0 || idx >= len(dropdownMenuItems) {
+	items := s.MenuItems()
+	if idx < 0 || idx >= len(items) {
 		return false
 	}
 	s.MenuHoverIndex = idx
 	s.MenuSubHoverIndex = -1
 	s.MenuSubOffset = 0
-	kind, ok := submenuKindByLabel[dropdownMenuItems[idx].Label]
+	kind, ok := s.submenuKind(items[idx].Label)
 	if !ok {
 		s.CloseSubmenu()
 		return false
@@ -71,8 +72,9 @@ func (s *AppState) MoveMenuSelection(delta int) {
 	if !s.MenuOpen || delta == 0 {
 		return
 	}
-	s.MenuHoverIndex = nextSelectableIndex(dropdownMenuItems, s.MenuHoverIndex, delta)
-	if s.MenuHoverIndex >= 0 && s.MenuHoverIndex < len(dropdownMenuItems) {
+	items := s.MenuItems()
+	s.MenuHoverIndex = nextSelectableIndex(items, s.MenuHoverIndex, delta)
+	if s.MenuHoverIndex >= 0 && s.MenuHoverIndex < len(items) {
 		s.OpenSubmenuForMenuIndex(s.MenuHoverIndex)
 		s.MenuSubHoverIndex = -1
 		s.MenuSubOffset = 0
@@ -91,7 +93,7 @@ func (s *AppState) MoveMenuSubmenuSelection(delta int) {
 
 func (s *AppState) ensureMenuScrollVisible() {
 	_, _, _, h := s.MenuPanelRect()
-	clampScrollSelection(dropdownMenuItems, &s.MenuHoverIndex, &s.MenuOffset, menuPageSizeForRectHeight(h))
+	clampScrollSelection(s.MenuItems(), &s.MenuHoverIndex, &s.MenuOffset, menuPageSizeForRectHeight(h))
 	if s.MenuSubmenuKind != "" {
 		_, _, _, sh := s.MenuSubmenuRect()
 		items := s.MenuSubmenuItems()
@@ -15,30 +15,95 @@ var dropdownMenuItems = []DropdownMenuItem{
 	{Separator: true},
 	{Label: "Commit", HasChevron: true},
 	{Label: "Changes", HasChevron: true},
+	{Label: "Branch", HasChevron: true},
+	{Label: "History", HasChevron: true},
+	{Label: "Merge", HasChevron: true},
+	{Label: "Rebase", HasChevron: true},
+	{Label: "Stash", HasChevron: true},
 }
 
 var commitDropdownMenuItems = []DropdownMenuItem{
 	{Label: "Commit Staged"},
 	{Label: "Commit All"},
+	{Label: "Amend Last Commit"},
 	{Label: "Undo Last Commit"},
+	{Separator: true},
+	{Label: "Add Trailer..."},
+	{Label: "Commit Type..."},
+	{Label: "Edit Message in Editor..."},
 }
 
 var changesDropdownMenuItems = []DropdownMenuItem{
 	{Label: "Stage All Changes"},
 	{Label: "Unstage All Changes"},
 	{Label: "Discard All Changes"},
+	{Label: "Selected File..."},
+	{Separator: true},
+	{Label: "Undo Last Discard"},
+	{Label: "Discard Snapshots..."},
+}
+
+var branchDropdownMenuItems = []DropdownMenuItem{
+	{Label: "Rename Branch..."},
+	{Label: "Delete Branch..."},
+	{Separator: true},
+	{Label: "Set Upstream..."},
+	{Label: "Unset Upstream"},
+	{Label: "Delete Remote Branch..."},
+	{Separator: true},
+	{Label: "Create Tag..."},
+	{Label: "Delete Tag..."},
+	{Label: "Push Tag"},
+}
+
+var historyDropdownMenuItems = []DropdownMenuItem{
+	{Label: "Cherry-pick Commits..."},
+	{Label: "Revert Commit..."},
+	{Label: "Reset to Commit..."},
+}
+
+var mergeDropdownMenuItems = []DropdownMenuItem{
+	{Label: "Merge Branch..."},
+	{Separator: true},
+	{Label: "Continue Merge"},
+	{Label: "Abort Merge"},
+}
+
+var rebaseDropdownMenuItems = []DropdownMenuItem{
+	{Label: "Interactive Rebase..."},
+	{Separator: true},
+	{Label: "Continue Rebase"},
+	{Label: "Skip Commit"},
+	{Label: "Abort Rebase"},
+}
+
+var stashDropdownMenuItems = []DropdownMenuItem{
+	{Label: "Stash Changes..."},
+	{Label: "Apply Stash"},
+	{Label: "Pop Stash"},
+	{Label: "Drop Stash"},
 }
 
 // submenuItemsByKind maps a submenu kind to its menu items.
 var submenuItemsByKind = map[string][]DropdownMenuItem{
 	"commit":  commitDropdownMenuItems,
 	"changes": changesDropdownMenuItems,
+	"branch":  branchDropdownMenuItems,
+	"history": historyDropdownMenuItems,
+	"merge":   mergeDropdownMenuItems,
+	"rebase":  rebaseDropdownMenuItems,
+	"stash":   stashDropdownMenuItems,
 }
 
 // submenuKindByLabel maps a main menu item label to its submenu kind.
 var submenuKindByLabel = map[string]string{
 	"Commit":  "commit",
 	"Changes": "changes",
+	"Branch":  "branch",
+	"History": "history",
+	"Merge":   "merge",
+	"Rebase":  "rebase",
+	"Stash":   "stash",
 }
 
 func dropdownItemsMaxWidth(items []DropdownMenuItem) int {
@@ -47,7 +112,8 @@ func dropdownItemsMaxWidth(items []DropdownMenuItem) int {
 		if item.Separator {
 			continue
 		}
-		itemW := runewidth.StringWidth(item.Label) + 2
+		// Selection prefix before the label and a space after it.
+		itemW := runewidth.StringWidth(item.Label) + 3
 		if item.HasChevron {
 			itemW += 2
 		}
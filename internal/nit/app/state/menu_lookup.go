@@ -1,15 +1,35 @@
 package state
 
+// MenuItems is the main menu, led by the in-progress submenu while git is
+// stopped in a merge, rebase, cherry-pick, revert or bisect.
 func (s AppState) MenuItems() []DropdownMenuItem {
-	return dropdownMenuItems
+	label := s.inProgressMenuLabel()
+	if label == "" {
+		return dropdownMenuItems
+	}
+	items := make([]DropdownMenuItem, 0, len(dropdownMenuItems)+2)
+	items = append(items, DropdownMenuItem{Label: label, HasChevron: true}, DropdownMenuItem{Separator: true})
+	return append(items, dropdownMenuItems...)
 }
 
 func (s AppState) MenuSubmenuItems() []DropdownMenuItem {
+	if s.MenuSubmenuKind == inProgressKind {
+		return s.inProgressMenuItems()
+	}
 	return submenuItemsByKind[s.MenuSubmenuKind]
 }
 
+// submenuKind returns the submenu opened by the main menu item label.
+func (s AppState) submenuKind(label string) (string, bool) {
+	if label != "" && label == s.inProgressMenuLabel() {
+		return inProgressKind, true
+	}
+	kind, ok := submenuKindByLabel[label]
+	return kind, ok
+}
+
 func (s AppState) firstSelectableMenuIndex() int {
-	return firstSelectableIndex(dropdownMenuItems)
+	return firstSelectableIndex(s.MenuItems())
 }
 
 func (s AppState) firstSelectableSubmenuIndex() int {
@@ -17,15 +37,16 @@ func (s AppState) firstSelectableSubmenuIndex() int {
 }
 
 func (s AppState) MenuHoverHasSubmenu() bool {
-	if s.MenuHoverIndex < 0 || s.MenuHoverIndex >= len(dropdownMenuItems) {
+	items := s.MenuItems()
+	if s.MenuHoverIndex < 0 || s.MenuHoverIndex >= len(items) {
 		return false
 	}
-	return dropdownMenuItems[s.MenuHoverIndex].HasChevron
+	return items[s.MenuHoverIndex].HasChevron
 }
 
 func (s AppState) submenuAnchorIndex() int {
-	for i, item := range dropdownMenuItems {
-		if kind, ok := submenuKindByLabel[item.Label]; ok && kind == s.MenuSubmenuKind {
+	for i, item := range s.MenuItems() {
+		if kind, ok := s.submenuKind(item.Label); ok && kind == s.MenuSubmenuKind {
 			return i
 		}
 	}
@@ -43,11 +64,12 @@ func (s AppState) MenuItemIndexAt(x, y int) (int, bool) {
 	if y == my || y == my+mh-1 {
 		return -1, false
 	}
+	items := s.MenuItems()
 	idx := s.MenuOffset + (y - my - 1)
-	if idx < 0 || idx >= len(dropdownMenuItems) {
+	if idx < 0 || idx >= len(items) {
 		return -1, false
 	}
-	if dropdownMenuItems[idx].Separator {
+	if items[idx].Separator {
 		return -1, false
 	}
 	return idx, true
@@ -1,6 +1,7 @@
 package state
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/mattn/go-runewidth"
@@ -8,24 +9,7 @@ import (
 
 func (s AppState) topBarBoxes() (fetchX, fetchW, menuX, menuW int) {
 	totalW := max(40, s.Viewport.Width)
-	repoName := s.RepoName
-	if repoName == "" {
-		repoName = "unknown"
-	}
-	branchName := s.BranchName
-	if branchName == "" {
-		branchName = "-"
-	}
-
-	sep := strings.TrimSpace(s.RepoBranchSeparator)
-	if sep == "" {
-		sep = "->"
-	}
-	repoText := strings.TrimSpace(
-		strings.TrimSpace(s.RepoLabel+" "+repoName) +
-			" " + sep + " " +
-			strings.TrimSpace(s.BranchLabel+" "+branchName),
-	)
+	repoText := s.TopBarRepoText()
 	createText := strings.TrimSpace(s.BranchesCreateButtonLabel())
 	fetchText := strings.TrimSpace(s.FetchLabel)
 	menuText := strings.TrimSpace(s.MenuLabel)
@@ -72,24 +56,7 @@ func (s AppState) topBarBoxes() (fetchX, fetchW, menuX, menuW int) {
 
 func (s AppState) topBarBoxRects() (repoX, repoW, branchX, branchW, menuX, menuW int) {
 	totalW := max(40, s.Viewport.Width)
-	repoName := s.RepoName
-	if repoName == "" {
-		repoName = "unknown"
-	}
-	branchName := s.BranchName
-	if branchName == "" {
-		branchName = "-"
-	}
-
-	sep := strings.TrimSpace(s.RepoBranchSeparator)
-	if sep == "" {
-		sep = "->"
-	}
-	repoText := strings.TrimSpace(
-		strings.TrimSpace(s.RepoLabel+" "+repoName) +
-			" " + sep + " " +
-			strings.TrimSpace(s.BranchLabel+" "+branchName),
-	)
+	repoText := s.TopBarRepoText()
 	createText := strings.TrimSpace(s.BranchesCreateButtonLabel())
 	fetchText := strings.TrimSpace(s.FetchLabel)
 	menuText := strings.TrimSpace(s.MenuLabel)
@@ -162,9 +129,10 @@ func (s AppState) menuMaxPanelHeight(y int) int {
 func (s AppState) MenuPanelRect() (x, y, w, h int) {
 	totalW := max(40, s.Viewport.Width)
 	_, _, menuX, menuW := s.topBarBoxes()
-	maxItemW := dropdownItemsMaxWidth(dropdownMenuItems)
+	items := s.MenuItems()
+	maxItemW := dropdownItemsMaxWidth(items)
 	w = max(18, max(menuW+10, maxItemW+2))
-	h = min(len(dropdownMenuItems)+2, s.menuMaxPanelHeight(3))
+	h = min(len(items)+2, s.menuMaxPanelHeight(3))
 	x = menuX + menuW - w
 	if x < 0 {
 		x = 0
@@ -201,3 +169,44 @@ func (s AppState) MenuSubmenuRect() (x, y, w, h int) {
 	}
 	return x, y, w, h
 }
+
+// TopBarRepoText is the text of the repository box in the top bar: repo,
+// branch and, when the branch tracks an upstream, its ahead/behind counts.
+func (s AppState) TopBarRepoText() string {
+	repoName := s.RepoName
+	if repoName == "" {
+		repoName = "unknown"
+	}
+	branchName := s.BranchName
+	if branchName == "" {
+		branchName = "-"
+	}
+
+	sep := strings.TrimSpace(s.RepoBranchSeparator)
+	if sep == "" {
+		sep = "->"
+	}
+	text := strings.TrimSpace(
+		strings.TrimSpace(s.RepoLabel+" "+repoName) +
+			" " + sep + " " +
+			strings.TrimSpace(s.BranchLabel+" "+branchName),
+	)
+	if s.BranchUpstream != "" {
+		if counts := AheadBehindText(s.BranchAhead, s.BranchBehind); counts != "" {
+			text += " " + counts
+		}
+	}
+	return text
+}
+
+// AheadBehindText renders upstream counts as "↑2 ↓5", leaving out zeros.
+func AheadBehindText(ahead, behind int) string {
+	parts := make([]string, 0, 2)
+	if ahead > 0 {
+		parts = append(parts, fmt.Sprintf("↑%d", ahead))
+	}
+	if behind > 0 {
+		parts = append(parts, fmt.Sprintf("↓%d", behind))
+	}
+	return strings.Join(parts, " ")
+}
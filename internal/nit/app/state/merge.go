@@ -0,0 +1,229 @@
+package state
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/zGIKS/nit/internal/nit/app/actions"
+	"github.com/zGIKS/nit/internal/nit/git"
+)
+
+// OpenMergeDialog offers the ways to merge the ref under the Branches cursor
+// into the current branch.
+func (s *AppState) OpenMergeDialog() {
+	if s.Focus != FocusBranches {
+		s.SetError("select the branch to merge in the Branches panel")
+		return
+	}
+	b, ok := s.SelectedBranch()
+	if !ok {
+		s.SetError("no branch selected")
+		return
+	}
+	if b.Head {
+		s.SetError("cannot merge " + b.Name + " into itself")
+		return
+	}
+	merged := actions.ApplyResult{RefreshChanges: true, RefreshGraph: true, RefreshRepoSummary: true}
+	option := func(label string, mode git.MergeMode) DialogOption {
+		res := merged
+		res.Operations = []actions.Operation{{Kind: actions.OpMerge, Ref: b.Name, MergeMode: string(mode)}}
+		return DialogOption{Label: label, Result: res}
+	}
+	s.OpenDialog(DialogState{
+		Title: fmt.Sprintf("Merge %s into %s", b.Name, s.BranchName),
+		Lines: []string{
+			"Merge fast-forwards when it can and creates a merge commit otherwise.",
+			"Fast-forward only stops instead of creating a merge commit.",
+		},
+		Options: []DialogOption{
+			option("Merge "+b.Name, git.MergeDefault),
+			option("Fast-forward only", git.MergeFastForward),
+			option("Always create a merge commit (--no-ff)", git.MergeNoFF),
+			{Label: "Cancel"},
+		},
+	})
+}
+
+// OpenMergeConflictDialog reports a merge, cherry-pick or revert (state)
+// that stopped on conflicts and points the Changes panel at them.
+func (s *AppState) OpenMergeConflictDialog(files []string, state git.RepoState) {
+	if _, ok := inProgressOps[state]; !ok || state == git.RepoBisecting {
+		state = git.RepoMerging
+	}
+	lines := []string{fmt.Sprintf("%d file(s) have conflicts:", len(files))}
+	const maxFiles = 5
+	for i, f := range files {
+		if i == maxFiles {
+			lines = append(lines, fmt.Sprintf("  ... and %d more", len(files)-maxFiles))
+			break
+		}
+		lines = append(lines, "  "+f)
+	}
+	lines = append(lines, fmt.Sprintf("Open each one from Merge Conflicts in Changes, then continue the %s.", state))
+	s.Focus = FocusChanges
+	s.Changes.StickySection = SectionConflicted
+	abort := inProgressOps[state]
+	s.OpenDialog(DialogState{
+		Title: strings.ToUpper(string(state[:1])) + string(state[1:]) + " conflicts",
+		Lines: lines,
+		Options: []DialogOption{
+			{Label: "Resolve conflicts"},
+			{Label: "Abort " + string(state), Result: actions.ApplyResult{
+				Operations:         []actions.Operation{{Kind: abort[2]}},
+				RefreshChanges:     true,
+				RefreshGraph:       true,
+				RefreshRepoSummary: true,
+			}},
+		},
+	})
+}
+
+// OpenConflictView opens the conflict view of a conflicted file. The file is
+// loaded separately.
+func (s *AppState) OpenConflictView(path string) {
+	s.CloseMenu()
+	s.CloseBranchCreate()
+	s.Conflict = ConflictState{Open: true, Path: path}
+}
+
+func (s *AppState) CloseConflictView() {
+	s.Conflict = ConflictState{}
+}
+
+// ConflictTarget reports the file whose conflicts still have to be loaded.
+func (s AppState) ConflictTarget() (string, bool) {
+	c := s.Conflict
+	return c.Path, c.Open && !c.Loaded && !c.Loading
+}
+
+func (s *AppState) BeginConflictLoad() {
+	s.Conflict.Loading = true
+}
+
+func (s *AppState) SetConflict(f git.ConflictFile) {
+	if !s.Conflict.Open || s.Conflict.Path != f.Path {
+		return
+	}
+	s.Conflict.File = f
+	s.Conflict.Choices = make([]git.ConflictChoice, len(f.Hunks))
+	s.Conflict.Loading = false
+	s.Conflict.Loaded = true
+	s.Conflict.Hunk = 0
+	s.scrollToConflictHunk()
+}
+
+func (s *AppState) MoveConflictHunk(delta int) {
+	n := len(s.Conflict.File.Hunks)
+	if n == 0 {
+		return
+	}
+	s.Conflict.Hunk = max(0, min(s.Conflict.Hunk+delta, n-1))
+	s.scrollToConflictHunk()
+}
+
+// ChooseConflictSide picks the side kept for the block under the cursor and
+// moves on to the next block that has no choice yet.
+func (s *AppState) ChooseConflictSide(choice git.ConflictChoice) {
+	c := &s.Conflict
+	if c.Hunk < 0 || c.Hunk >= len(c.Choices) {
+		return
+	}
+	c.Choices[c.Hunk] = choice
+	for i := 1; i < len(c.Choices); i++ {
+		next := (c.Hunk + i) % len(c.Choices)
+		if c.Choices[next] == git.ConflictUnresolved {
+			c.Hunk = next
+			break
+		}
+	}
+	s.scrollToConflictHunk()
+}
+
+// ConflictsLeft counts the blocks that have no side chosen yet.
+func (s AppState) ConflictsLeft() int {
+	left := 0
+	for _, c := range s.Conflict.Choices {
+		if c == git.ConflictUnresolved {
+			left++
+		}
+	}
+	return left
+}
+
+// ResolveConflict writes the chosen sides into the file and marks it
+// resolved. A file without conflict markers, e.g. one fixed in an editor, is
+// marked resolved as it is.
+func (s *AppState) ResolveConflict() actions.ApplyResult {
+	c := s.Conflict
+	if !c.Loaded {
+		return actions.ApplyResult{}
+	}
+	op := actions.Operation{Kind: actions.OpStagePath, Path: c.Path}
+	if len(c.File.Hunks) > 0 {
+		content, ok := c.File.Resolve(c.Choices)
+		if !ok {
+			s.SetError(fmt.Sprintf("%d conflict(s) in %s still need a side", s.ConflictsLeft(), c.Path))
+			return actions.ApplyResult{}
+		}
+		op = actions.Operation{Kind: actions.OpResolveConflict, Path: c.Path, Content: content}
+	}
+	s.CloseConflictView()
+	s.Changes.StickySection = SectionConflicted
+	return actions.ApplyResult{Operations: []actions.Operation{op}, RefreshChanges: true}
+}
+
+func (s *AppState) ScrollConflict(delta int) {
+	s.Conflict.Offset = max(0, min(s.Conflict.Offset+delta, s.conflictMaxOffset()))
+}
+
+// ConflictCursorLine is the line of the file the cursor is on: the opening
+// marker of the current block.
+func (s AppState) ConflictCursorLine() int {
+	c := s.Conflict
+	if c.Hunk < 0 || c.Hunk >= len(c.File.Hunks) {
+		return -1
+	}
+	return c.File.Hunks[c.Hunk].Start
+}
+
+// scrollToConflictHunk shows the current block with a little context above
+// it, or from its top when it is taller than the view.
+func (s *AppState) scrollToConflictHunk() {
+	c := &s.Conflict
+	if c.Hunk < 0 || c.Hunk >= len(c.File.Hunks) {
+		return
+	}
+	h := c.File.Hunks[c.Hunk]
+	page := s.conflictPageSize()
+	if h.Start >= c.Offset && h.End < c.Offset+page {
+		return
+	}
+	c.Offset = max(0, min(h.Start-2, s.conflictMaxOffset()))
+}
+
+func (s AppState) ConflictPanelRect() (x, y, w, h int) {
+	return s.RebasePanelRect()
+}
+
+func (s AppState) conflictPageSize() int {
+	_, _, _, h := s.ConflictPanelRect()
+	return max(1, h-2)
+}
+
+func (s AppState) conflictMaxOffset() int {
+	return max(0, len(s.Conflict.File.Lines)-s.conflictPageSize())
+}
+
+// ConflictClick swallows clicks while the conflict view is open.
+func (s *AppState) ConflictClick(x, y int) bool {
+	return s.Conflict.Open
+}
+
+func (s *AppState) ConflictWheel(delta int) bool {
+	if !s.Conflict.Open {
+		return false
+	}
+	s.ScrollConflict(delta)
+	return true
+}
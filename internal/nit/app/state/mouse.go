@@ -17,7 +17,7 @@ func (s *AppState) HandleMouseClick(x, y int) {
 	}
 	top += s.CommandPaneHeight()
 
-	if s.clickChangesBox(y, top) {
+	if s.clickChangesBox(x, y, top) {
 		s.Clamp()
 		return
 	}
@@ -61,7 +61,7 @@ func (s *AppState) HandleMouseWheel(x, y, delta int) {
 	}
 	top += s.CommandPaneHeight()
 
-	if s.wheelChangesBox(y, top, delta) {
+	if s.wheelChangesBox(x, y, top, delta) {
 		s.Clamp()
 		return
 	}
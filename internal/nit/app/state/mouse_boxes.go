@@ -18,11 +18,22 @@ func (s *AppState) wheelCommandBox(y, top, delta int) bool {
 	return true
 }
 
-func (s *AppState) clickChangesBox(y, top int) bool {
+func (s *AppState) clickChangesBox(x, y, top int) bool {
 	h := s.ChangesPaneHeight()
 	if y < top || y >= top+h {
 		return false
 	}
+	listW, _ := s.ChangesDiffPaneWidths()
+	if x > listW {
+		s.focusByMouse(FocusDiff)
+		if idx, ok := boxContentLine(y, top, h); ok {
+			line := s.Diff.Offset + idx
+			if line >= 0 && line < len(s.Diff.Lines) {
+				s.Diff.Cursor = line
+			}
+		}
+		return true
+	}
 	s.focusByMouse(FocusChanges)
 	if idx, ok := boxContentLine(y, top, h); ok {
 		row := s.Changes.Offset + idx
@@ -33,11 +44,17 @@ func (s *AppState) clickChangesBox(y, top int) bool {
 	return true
 }
 
-func (s *AppState) wheelChangesBox(y, top, delta int) bool {
+func (s *AppState) wheelChangesBox(x, y, top, delta int) bool {
 	h := s.ChangesPaneHeight()
 	if y < top || y >= top+h {
 		return false
 	}
+	listW, _ := s.ChangesDiffPaneWidths()
+	if x > listW {
+		s.focusByMouse(FocusDiff)
+		s.Diff.Cursor += delta
+		return true
+	}
 	s.focusByMouse(FocusChanges)
 	s.Changes.Cursor += delta
 	if delta >= 0 {
@@ -53,7 +70,17 @@ func (s *AppState) clickGraphBox(x, y, top int) bool {
 	if y < top || y >= top+h {
 		return false
 	}
-	graphW, _ := s.GraphBranchesPaneWidths()
+	graphW, branchW, _ := s.GraphRowPaneWidths()
+	if x > graphW+1+branchW {
+		s.focusByMouse(FocusStash)
+		if idx, ok := boxContentLine(y, top, h); ok {
+			line := s.Stash.Offset + idx
+			if line >= 0 && line < len(s.Stash.Lines) {
+				s.Stash.Cursor = line
+			}
+		}
+		return true
+	}
 	if x > graphW {
 		s.focusByMouse(FocusBranches)
 		if idx, ok := boxContentLine(y, top, h); ok {
@@ -79,7 +106,12 @@ func (s *AppState) wheelGraphBox(x, y, top, delta int) bool {
 	if y < top || y >= top+h {
 		return false
 	}
-	graphW, _ := s.GraphBranchesPaneWidths()
+	graphW, branchW, _ := s.GraphRowPaneWidths()
+	if x > graphW+1+branchW {
+		s.focusByMouse(FocusStash)
+		s.Stash.Cursor += delta
+		return true
+	}
 	if x > graphW {
 		s.focusByMouse(FocusBranches)
 		s.Branches.Cursor += delta
@@ -144,3 +176,31 @@ func boxContentLine(y, top, boxHeight int) (int, bool) {
 	}
 	return y - contentTop, true
 }
+
+// OpenFileMenuAt selects the change under a right click and opens its file
+// menu. It does nothing outside of the Changes list or while another window
+// is open.
+func (s *AppState) OpenFileMenuAt(x, y int) bool {
+	if s.MenuOpen || s.BranchCreateOpen || s.Confirm.Open || s.Dialog.Open || s.Prompt.Open || s.CommitDetail.Open ||
+		s.Rebase.Open || s.Conflict.Open || s.Reset.Open || s.OpOutput.Open {
+		return false
+	}
+	top := s.CommandPaneHeight()
+	h := s.ChangesPaneHeight()
+	listW, _ := s.ChangesDiffPaneWidths()
+	if y < top || y >= top+h || x > listW {
+		return false
+	}
+	idx, ok := boxContentLine(y, top, h)
+	if !ok {
+		return false
+	}
+	row := s.Changes.Offset + idx
+	if row < 0 || row >= len(s.Changes.Rows) || !s.Changes.Rows[row].Selectable {
+		return false
+	}
+	s.focusByMouse(FocusChanges)
+	s.Changes.Cursor = row
+	s.OpenFileMenu()
+	return true
+}
@@ -32,7 +32,7 @@ func (s *AppState) HandleMouseMove(x, y int) {
 	s.MenuSubHoverIndex = -1
 	if s.MenuOpen && menuOK {
 		if item := s.MenuItems()[menuIdx]; item.HasChevron {
-			if kind, ok := submenuKindByLabel[item.Label]; ok {
+			if kind, ok := s.submenuKind(item.Label); ok {
 				s.MenuSubmenuKind = kind
 			}
 		}
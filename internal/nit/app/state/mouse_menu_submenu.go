@@ -27,9 +27,31 @@ func (s *AppState) MenuSubmenuActivateIndex(idx int) (actions.Action, bool, bool
 			s.CloseMenu()
 			s.PrepareCommandCommit(true, false, false)
 			return actions.ActionNone, false, true
+		case "Amend Last Commit":
+			s.CloseMenu()
+			s.PrepareCommandCommit(false, true, false)
+			return actions.ActionNone, false, true
 		case "Undo Last Commit":
 			s.CloseMenu()
 			return actions.ActionUndoLastCommit, true, true
+		case "Add Trailer...":
+			s.CloseMenu()
+			s.PrepareCommandCommit(s.Command.CommitAll, s.Command.CommitAmend, s.Command.CommitSignoff)
+			s.SyncCommitTemplate()
+			s.OpenCommitTrailerPicker()
+			return actions.ActionNone, false, true
+		case "Commit Type...":
+			s.CloseMenu()
+			s.PrepareCommandCommit(s.Command.CommitAll, s.Command.CommitAmend, s.Command.CommitSignoff)
+			s.SyncCommitTemplate()
+			s.OpenCommitTypePicker()
+			return actions.ActionNone, false, true
+		case "Edit Message in Editor...":
+			s.CloseMenu()
+			s.PrepareCommandCommit(s.Command.CommitAll, s.Command.CommitAmend, s.Command.CommitSignoff)
+			s.SyncCommitTemplate()
+			s.RequestCommitMessageEditor()
+			return actions.ActionNone, false, true
 		}
 	case "changes":
 		switch item.Label {
@@ -48,6 +70,98 @@ func (s *AppState) MenuSubmenuActivateIndex(idx int) (actions.Action, bool, bool
 			s.Focus = FocusChanges
 			s.snapChangesCursor(1)
 			return actions.ActionDiscardAll, true, true
+		case "Selected File...":
+			s.CloseMenu()
+			s.Focus = FocusChanges
+			s.snapChangesCursor(1)
+			return actions.ActionFileMenu, true, true
+		case "Undo Last Discard":
+			s.CloseMenu()
+			return actions.ActionUndoDiscard, true, true
+		case "Discard Snapshots...":
+			s.CloseMenu()
+			return actions.ActionDiscardSnapshots, true, true
+		}
+	case "branch":
+		s.CloseMenu()
+		s.Focus = FocusBranches
+		switch item.Label {
+		case "Rename Branch...":
+			return actions.ActionBranchRename, true, true
+		case "Delete Branch...":
+			return actions.ActionBranchDelete, true, true
+		case "Set Upstream...":
+			return actions.ActionBranchUpstream, true, true
+		case "Unset Upstream":
+			return actions.ActionBranchUnsetUpstream, true, true
+		case "Delete Remote Branch...":
+			return actions.ActionBranchDeleteRemote, true, true
+		case "Create Tag...":
+			return actions.ActionTagCreate, true, true
+		case "Delete Tag...":
+			s.OpenTagDeleteDialog()
+			return actions.ActionNone, false, true
+		case "Push Tag":
+			return actions.ActionTagPush, true, true
+		}
+	case "history":
+		s.CloseMenu()
+		s.Focus = FocusGraph
+		switch item.Label {
+		case "Cherry-pick Commits...":
+			return actions.ActionCherryPick, true, true
+		case "Revert Commit...":
+			return actions.ActionRevert, true, true
+		case "Reset to Commit...":
+			return actions.ActionReset, true, true
+		}
+	case "merge":
+		s.CloseMenu()
+		switch item.Label {
+		case "Merge Branch...":
+			s.Focus = FocusBranches
+			return actions.ActionMerge, true, true
+		case "Continue Merge":
+			return actions.ActionMergeContinue, true, true
+		case "Abort Merge":
+			return actions.ActionMergeAbort, true, true
+		}
+	case "rebase":
+		s.CloseMenu()
+		switch item.Label {
+		case "Interactive Rebase...":
+			s.Focus = FocusGraph
+			return actions.ActionRebaseInteractive, true, true
+		case "Continue Rebase":
+			return actions.ActionRebaseContinue, true, true
+		case "Skip Commit":
+			return actions.ActionRebaseSkip, true, true
+		case "Abort Rebase":
+			return actions.ActionAbortRebase, true, true
+		}
+	case inProgressKind:
+		s.CloseMenu()
+		if action := inProgressMenuAction(item.Label); action != actions.ActionNone {
+			return action, true, true
+		}
+	case "stash":
+		switch item.Label {
+		case "Stash Changes...":
+			s.CloseMenu()
+			s.OpenStashPrompt()
+			return actions.ActionNone, false, true
+		case "Apply Stash":
+			s.CloseMenu()
+			s.Focus = FocusStash
+			return actions.ActionStashApply, true, true
+		case "Pop Stash":
+			s.CloseMenu()
+			s.Focus = FocusStash
+			return actions.ActionStashPop, true, true
+		case "Drop Stash":
+			s.CloseMenu()
+			s.Focus = FocusStash
+			return actions.ActionStashDrop, true, true
 		}
 	}
 	s.CloseMenu()
@@ -1,6 +1,8 @@
 package state
 
 import (
+"strings"
+
 "github.com/zGIKS/nit/internal/nit/app/actions"
 )
 
@@ -22,10 +24,14 @@ case FocusCommand:
 s.Focus = FocusChanges
 s.snapChangesCursor(1)
 case FocusChanges:
+s.Focus = FocusDiff
+case FocusDiff:
 s.Focus = FocusGraph
 case FocusGraph:
 s.Focus = FocusBranches
 case FocusBranches:
+s.Focus = FocusStash
+case FocusStash:
 s.Focus = FocusCommandLog
 default:
 s.Command.ReturnFocus = FocusBranches
@@ -37,21 +43,38 @@ case actions.ActionMoveUp:
 s.moveCursor(-1)
 case actions.ActionToggleOne:
 if s.Focus == FocusCommand {
-msg := s.Command.Input
+msg := s.CommitMessage()
 if msg == "" {
 break
 }
+subject := strings.TrimSpace(s.CommitEditorLines()[0])
+if subject == "" {
+s.SetError("commit subject is empty")
+break
+}
+if s.commitTemplateUnedited() {
+s.SetError("commit message template was not edited")
+break
+}
+if s.Command.Conventional {
+if reason := s.conventionalSubjectError(subject); reason != "" {
+s.SetError(reason)
+break
+}
+}
 needsStaged := !s.Command.CommitAll && !s.Command.CommitAmend
 if needsStaged && len(s.Changes.Staged) == 0 {
 s.SetError("nothing staged to commit")
 break
 }
 res.Operations = []actions.Operation{{
-Kind:          actions.OpCommit,
-Message:       msg,
-CommitAll:     s.Command.CommitAll,
-CommitAmend:   s.Command.CommitAmend,
-CommitSignoff: s.Command.CommitSignoff,
+Kind:              actions.OpCommit,
+Message:           msg,
+CommitAll:         s.Command.CommitAll,
+CommitAmend:       s.Command.CommitAmend,
+CommitSignoff:     s.Command.CommitSignoff,
+CommitSign:        s.Command.CommitSign,
+CommitSignDefault: s.Command.SignByDefault,
 }}
 res.RefreshChanges = true
 res.RefreshGraph = true
@@ -61,6 +84,11 @@ s.Command.SelectAll = false
 s.clearCommandCommitOptions()
 break
 }
+if s.Focus == FocusDiff {
+res.Operations = s.applyDiffSelection()
+res.RefreshChanges = len(res.Operations) > 0
+break
+}
 if s.Focus != FocusChanges {
 break
 }
@@ -68,6 +96,10 @@ entry, section, ok := s.selectedChange()
 if !ok {
 break
 }
+if section == SectionConflicted {
+s.OpenConflictView(entry.Path)
+break
+}
 if section == SectionStaged {
 s.Changes.StickySection = SectionStaged
 res.Operations = []actions.Operation{{Kind: actions.OpUnstagePath, Path: entry.Path}}
@@ -106,6 +138,8 @@ break
 }
 res.Operations = []actions.Operation{{Kind: actions.OpPush}}
 res.RefreshGraph = true
+case actions.ActionCommitAmend:
+s.PrepareCommandCommit(false, true, false)
 case actions.ActionUndoLastCommit:
 res.Operations = []actions.Operation{{Kind: actions.OpUndoLastCommit}}
 res.RefreshChanges = true
@@ -114,6 +148,101 @@ case actions.ActionAbortRebase:
 res.Operations = []actions.Operation{{Kind: actions.OpAbortRebase}}
 res.RefreshChanges = true
 res.RefreshGraph = true
+res.RefreshRepoSummary = true
+case actions.ActionToggleLine:
+switch s.Focus {
+case FocusDiff:
+s.ToggleDiffLineMark()
+case FocusGraph:
+s.ToggleGraphMark()
+}
+case actions.ActionDiscardSelection:
+if s.Focus == FocusDiff {
+res.Operations = s.discardDiffSelection()
+res.RefreshChanges = len(res.Operations) > 0
+}
+if s.Focus == FocusChanges {
+res = s.discardSelectedFile()
+}
+case actions.ActionStashPush:
+s.OpenStashPrompt()
+case actions.ActionStashApply:
+res.Operations = s.stashOperation(actions.OpStashApply)
+res.RefreshChanges = len(res.Operations) > 0
+case actions.ActionStashPop:
+res.Operations = s.stashOperation(actions.OpStashPop)
+res.RefreshChanges = len(res.Operations) > 0
+res.RefreshGraph = len(res.Operations) > 0
+case actions.ActionStashDrop:
+res.Operations = s.stashOperation(actions.OpStashDrop)
+res.RefreshGraph = len(res.Operations) > 0
+case actions.ActionBranchDelete:
+s.OpenBranchDeleteDialog()
+case actions.ActionBranchRename:
+s.OpenBranchRenamePrompt()
+case actions.ActionBranchUpstream:
+s.OpenBranchUpstreamPrompt()
+case actions.ActionBranchUnsetUpstream:
+res.Operations = s.branchOperation(actions.OpBranchUnsetUpstream)
+res.RefreshGraph = len(res.Operations) > 0
+case actions.ActionBranchDeleteRemote:
+res.Operations = s.branchOperation(actions.OpBranchDeleteRemote)
+res.RefreshGraph = len(res.Operations) > 0
+case actions.ActionTagCreate:
+s.OpenTagCreatePrompt()
+case actions.ActionTagPush:
+res.Operations = s.tagOperation(actions.OpTagPush)
+case actions.ActionRebaseInteractive:
+s.OpenRebaseEditor()
+case actions.ActionRebaseContinue:
+res.Operations = []actions.Operation{{Kind: actions.OpRebaseContinue}}
+res.RefreshChanges = true
+res.RefreshGraph = true
+res.RefreshRepoSummary = true
+case actions.ActionRebaseSkip:
+res.Operations = []actions.Operation{{Kind: actions.OpRebaseSkip}}
+res.RefreshChanges = true
+res.RefreshGraph = true
+res.RefreshRepoSummary = true
+case actions.ActionMerge:
+s.OpenMergeDialog()
+case actions.ActionMergeContinue:
+res.Operations = []actions.Operation{{Kind: actions.OpMergeContinue}}
+res.RefreshChanges = true
+res.RefreshGraph = true
+res.RefreshRepoSummary = true
+case actions.ActionMergeAbort:
+res.Operations = []actions.Operation{{Kind: actions.OpMergeAbort}}
+res.RefreshChanges = true
+res.RefreshGraph = true
+res.RefreshRepoSummary = true
+case actions.ActionCherryPick:
+s.OpenCherryPickDialog()
+case actions.ActionRevert:
+s.OpenRevertDialog()
+case actions.ActionReset:
+s.OpenResetDialog()
+case actions.ActionUndoDiscard:
+res = restoreSnapshotResult("")
+case actions.ActionDiscardSnapshots:
+s.OpenSnapshotList()
+case actions.ActionFileMenu:
+s.OpenFileMenu()
+case actions.ActionOpenInEditor:
+s.RequestOpenInEditor()
+case actions.ActionCopyPath:
+s.RequestCopyPath()
+case actions.ActionFileHistory:
+s.OpenFileHistory()
+case actions.ActionMarkDown:
+s.ExtendGraphMark(1)
+case actions.ActionMarkUp:
+s.ExtendGraphMark(-1)
+case actions.ActionContinueOperation, actions.ActionSkipOperation, actions.ActionAbortOperation:
+res.Operations = s.inProgressOperation(action)
+res.RefreshChanges = len(res.Operations) > 0
+res.RefreshGraph = len(res.Operations) > 0
+res.RefreshRepoSummary = len(res.Operations) > 0
 case actions.ActionMenuRight:
 if s.MenuOpen && s.MenuSubmenuKind == "" {
 s.OpenHoveredSubmenu()
@@ -123,6 +252,7 @@ if s.MenuOpen && s.MenuSubmenuKind != "" {
 s.CloseSubmenu()
 }
 }
+res = s.confirmDestructive(res)
 s.Clamp()
 return res
 }
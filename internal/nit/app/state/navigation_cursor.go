@@ -24,6 +24,16 @@ func (s *AppState) moveCursor(delta int) {
 		s.Clamp()
 		return
 	}
+	if s.Focus == FocusStash {
+		s.Stash.Cursor += delta
+		s.Clamp()
+		return
+	}
+	if s.Focus == FocusDiff {
+		s.Diff.Cursor += delta
+		s.Clamp()
+		return
+	}
 	if s.Focus == FocusCommandLog {
 		s.CommandLogView.Cursor += delta
 		s.Clamp()
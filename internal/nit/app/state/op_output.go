@@ -0,0 +1,203 @@
+package state
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/zGIKS/nit/internal/nit/app/actions"
+	"github.com/zGIKS/nit/internal/nit/git"
+)
+
+// BeginOpOutput starts collecting the output of a streamed operation. The
+// modal opens right away when the operation runs hooks.
+func (s *AppState) BeginOpOutput(id int, op actions.Operation, hooks []string) {
+	s.OpOutput = OpOutputState{ID: id, Op: op, Hooks: hooks, Running: true}
+	if len(hooks) > 0 {
+		s.CloseMenu()
+		s.CloseBranchCreate()
+		s.OpOutput.Open = true
+	}
+}
+
+// AppendOpOutput adds a line of output. The view keeps following the end of
+// the output unless it was scrolled up.
+func (s *AppState) AppendOpOutput(id int, line string) {
+	if id != s.OpOutput.ID {
+		return
+	}
+	following := s.OpOutput.Offset >= s.opOutputMaxOffset()
+	s.OpOutput.Lines = append(s.OpOutput.Lines, line)
+	if following {
+		s.OpOutput.Offset = s.opOutputMaxOffset()
+	}
+}
+
+func (s *AppState) SetOpProgress(id int, p git.Progress) {
+	if id != s.OpOutput.ID {
+		return
+	}
+	s.OpOutput.Progress = p
+}
+
+// CancelOpOutput marks the running operation as being cancelled and returns
+// its ID, or false when nothing is running.
+func (s *AppState) CancelOpOutput() (int, bool) {
+	o := &s.OpOutput
+	if !o.Running || o.Cancelling {
+		return 0, false
+	}
+	o.Cancelling = true
+	return o.ID, true
+}
+
+// FinishOpOutput records how a streamed operation ended. On success or when
+// it was cancelled the modal closes; on failure it stays open, or opens
+// again if it was hidden. A failed commit puts its message back into the
+// empty editor, and a commit that later succeeds clears it again.
+func (s *AppState) FinishOpOutput(id int, err error) {
+	o := &s.OpOutput
+	if id != o.ID {
+		return
+	}
+	o.Running = false
+	o.Cancelling = false
+	commit := o.Op.Kind == actions.OpCommit
+	if err == nil || errors.Is(err, git.ErrCancelled) {
+		o.Open = false
+	}
+	var conflict *git.MergeConflictError
+	if o.Op.Kind == actions.OpCherryPick && (err == nil || errors.As(err, &conflict)) {
+		// The marked commits have been picked, or are being picked.
+		s.Graph.Marked = nil
+	}
+	if err == nil {
+		if commit && s.Command.Input == o.Op.Message {
+			s.Command.Input = ""
+			s.Command.Cursor = 0
+			s.Command.SelectAll = false
+		}
+		return
+	}
+	if commit && strings.TrimSpace(s.Command.Input) == "" {
+		s.Command.Input = o.Op.Message
+		s.Command.Cursor = len([]rune(o.Op.Message))
+		s.Command.SelectAll = false
+	}
+	if errors.Is(err, git.ErrCancelled) {
+		return
+	}
+	o.Failed = true
+	if len(o.Hooks) > 0 {
+		o.Open = true
+		o.Offset = s.opOutputMaxOffset()
+	}
+}
+
+// CloseOpOutput hides the modal. A running operation keeps going.
+func (s *AppState) CloseOpOutput() {
+	s.OpOutput.Open = false
+}
+
+func (s *AppState) ScrollOpOutput(delta int) {
+	s.OpOutput.Offset = max(0, min(s.OpOutput.Offset+delta, s.opOutputMaxOffset()))
+}
+
+// OpOutputTitle names the operation and the hooks it runs.
+func (s AppState) OpOutputTitle() string {
+	o := s.OpOutput
+	verb := s.OpOutputVerb()
+	if o.Running {
+		return "Running " + verb + " hooks: " + strings.Join(o.Hooks, ", ")
+	}
+	return strings.ToUpper(verb[:1]) + verb[1:] + " failed"
+}
+
+// CanRetryWithoutHooks reports whether the failed operation can be run again
+// with --no-verify.
+func (s AppState) CanRetryWithoutHooks() bool {
+	o := s.OpOutput
+	return o.Failed && !o.Op.NoVerify && len(noVerifySkips(o.Op.Kind)) > 0
+}
+
+// ConfirmRetryWithoutHooks asks before running the failed operation again
+// with --no-verify.
+func (s *AppState) ConfirmRetryWithoutHooks() {
+	if !s.CanRetryWithoutHooks() {
+		return
+	}
+	op := s.OpOutput.Op
+	op.NoVerify = true
+	verb := s.OpOutputVerb()
+	s.OpenDialog(DialogState{
+		Title: "Skip hooks?",
+		Lines: []string{
+			"git " + verb + " --no-verify does not run " + strings.Join(noVerifySkips(op.Kind), " or ") + ".",
+			"Whatever those hooks check is skipped for this " + verb + ".",
+		},
+		Options: []DialogOption{
+			{Label: "Retry " + verb + " without hooks", Result: actions.ApplyResult{
+				Operations:         []actions.Operation{op},
+				RefreshChanges:     true,
+				RefreshGraph:       true,
+				RefreshRepoSummary: true,
+			}},
+			{Label: "Cancel"},
+		},
+	})
+}
+
+// OpOutputVerb is the git command of the streamed operation.
+func (s AppState) OpOutputVerb() string {
+	switch s.OpOutput.Op.Kind {
+	case actions.OpPush:
+		return "push"
+	case actions.OpPull:
+		return "pull"
+	case actions.OpFetch:
+		return "fetch"
+	case actions.OpMerge, actions.OpMergeContinue:
+		return "merge"
+	case actions.OpCherryPick, actions.OpCherryPickContinue, actions.OpCherryPickSkip:
+		return "cherry-pick"
+	case actions.OpRevert, actions.OpRevertContinue, actions.OpRevertSkip:
+		return "revert"
+	case actions.OpRebaseInteractive, actions.OpRebaseContinue, actions.OpRebaseSkip:
+		return "rebase"
+	}
+	return "commit"
+}
+
+// noVerifySkips lists the hooks --no-verify skips for an operation.
+func noVerifySkips(kind actions.OpKind) []string {
+	switch kind {
+	case actions.OpCommit:
+		return []string{"pre-commit", "commit-msg"}
+	case actions.OpPush:
+		return []string{"pre-push"}
+	case actions.OpPull, actions.OpMerge:
+		return []string{"pre-merge-commit", "commit-msg"}
+	}
+	return nil
+}
+
+func (s AppState) OpOutputPanelRect() (x, y, w, h int) {
+	return s.RebasePanelRect()
+}
+
+func (s AppState) opOutputMaxOffset() int {
+	_, _, _, h := s.OpOutputPanelRect()
+	return max(0, len(s.OpOutput.Lines)-max(1, h-2))
+}
+
+// OpOutputClick swallows clicks while the output modal is open.
+func (s *AppState) OpOutputClick(x, y int) bool {
+	return s.OpOutput.Open
+}
+
+func (s *AppState) OpOutputWheel(delta int) bool {
+	if !s.OpOutput.Open {
+		return false
+	}
+	s.ScrollOpOutput(delta)
+	return true
+}
@@ -1,7 +1,7 @@
 package state
 
 func (s AppState) ChangesPosition() (int, int) {
-	total := len(s.Changes.Staged) + len(s.Changes.Unstaged)
+	total := len(s.Changes.Conflicted) + len(s.Changes.Staged) + len(s.Changes.Unstaged)
 	if total < 1 {
 		return 1, 1
 	}
@@ -24,10 +24,18 @@ func (s AppState) ChangesPosition() (int, int) {
 	return cur, total
 }
 
+func (s AppState) StashPosition() (int, int) {
+	return linearPosition(len(s.Stash.Lines), s.Stash.Cursor)
+}
+
 func (s AppState) GraphPosition() (int, int) {
 	return linearPosition(len(s.Graph.Lines), s.Graph.Cursor)
 }
 
+func (s AppState) DiffPosition() (int, int) {
+	return linearPosition(len(s.Diff.Lines), s.Diff.Cursor)
+}
+
 func (s AppState) BranchesPosition() (int, int) {
 	return linearPosition(len(s.Branches.Lines), s.Branches.Cursor)
 }
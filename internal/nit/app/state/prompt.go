@@ -0,0 +1,170 @@
+package state
+
+import (
+	"strings"
+
+	"github.com/zGIKS/nit/internal/nit/app/actions"
+)
+
+func (s *AppState) OpenPrompt(p PromptState) {
+	s.CloseMenu()
+	s.CloseBranchCreate()
+	p.Open = true
+	moveTextInputCursorEnd(p.Input, &p.Cursor, &p.SelectAll)
+	s.Prompt = p
+}
+
+func (s *AppState) ClosePrompt() {
+	s.Prompt = PromptState{}
+}
+
+func (s *AppState) TogglePromptOption() {
+	if s.Prompt.OptionLabel != "" {
+		s.Prompt.Option = !s.Prompt.Option
+	}
+}
+
+// SubmitPrompt closes the prompt and turns its input into the operations its
+// kind stands for.
+func (s *AppState) SubmitPrompt() actions.ApplyResult {
+	p := s.Prompt
+	s.ClosePrompt()
+	res := actions.ApplyResult{}
+	switch p.Kind {
+	case PromptStashPush:
+		res.Operations = []actions.Operation{{Kind: actions.OpStashPush, Message: p.Input, IncludeUntracked: p.Option}}
+		res.RefreshChanges = true
+		res.RefreshGraph = true
+	case PromptBranchRename:
+		name := strings.TrimSpace(p.Input)
+		if name == "" || name == p.Target {
+			break
+		}
+		res.Operations = []actions.Operation{{Kind: actions.OpBranchRename, Ref: p.Target, Target: name}}
+		res.RefreshGraph = true
+		res.RefreshRepoSummary = true
+	case PromptBranchUpstream:
+		kind := actions.OpBranchSetUpstream
+		if strings.TrimSpace(p.Input) == "" {
+			kind = actions.OpBranchUnsetUpstream
+		}
+		res.Operations = []actions.Operation{{Kind: kind, Ref: p.Target, Target: strings.TrimSpace(p.Input)}}
+		res.RefreshGraph = true
+	case PromptBranchTrack:
+		name := strings.TrimSpace(p.Input)
+		if name == "" {
+			break
+		}
+		res.Operations = []actions.Operation{{Kind: actions.OpBranchTrack, Ref: p.Target, Target: name}}
+		res.RefreshChanges = true
+		res.RefreshGraph = true
+		res.RefreshRepoSummary = true
+	case PromptTagCreate:
+		name := strings.TrimSpace(p.Input)
+		if name == "" {
+			break
+		}
+		if p.Option {
+			s.openTagMessagePrompt(name, p.Target)
+			break
+		}
+		res.Operations = []actions.Operation{{Kind: actions.OpTagCreate, Ref: p.Target, Target: name}}
+		res.RefreshGraph = true
+	case PromptTagMessage:
+		if strings.TrimSpace(p.Input) == "" {
+			s.SetError("tag message is empty")
+			break
+		}
+		res.Operations = []actions.Operation{{Kind: actions.OpTagCreate, Ref: p.Target, Target: p.Value, Message: p.Input}}
+		res.RefreshGraph = true
+	case PromptRebaseReword:
+		s.setRebaseRewordMessage(p.Target, p.Input)
+	case PromptCommitTrailer:
+		s.addCommitTrailer(p.Value, p.Input)
+	case PromptCommitScope:
+		s.setConventionalPrefix(p.Value, p.Input, p.Option)
+	}
+	return res
+}
+
+// PromptInsertNewline starts a new line in a multi-line prompt.
+func (s *AppState) PromptInsertNewline() {
+	if s.Prompt.Multiline {
+		appendTextInput(&s.Prompt.Input, &s.Prompt.Cursor, &s.Prompt.SelectAll, "\n")
+	}
+}
+
+func (s *AppState) PromptCursorUp() {
+	s.Prompt.SelectAll = false
+	s.Prompt.Cursor = moveCursorVertical(s.Prompt.Input, s.Prompt.Cursor, -1)
+}
+
+func (s *AppState) PromptCursorDown() {
+	s.Prompt.SelectAll = false
+	s.Prompt.Cursor = moveCursorVertical(s.Prompt.Input, s.Prompt.Cursor, 1)
+}
+
+// PromptCursorLineCol returns the line and column (both in runes) of the
+// prompt cursor.
+func (s AppState) PromptCursorLineCol() (line, col int) {
+	return textLineCol(s.Prompt.Input, s.Prompt.Cursor)
+}
+
+func (s *AppState) PromptAppendText(text string) {
+	appendTextInput(&s.Prompt.Input, &s.Prompt.Cursor, &s.Prompt.SelectAll, text)
+}
+
+func (s *AppState) PromptBackspace() {
+	backspaceTextInput(&s.Prompt.Input, &s.Prompt.Cursor, &s.Prompt.SelectAll)
+}
+
+func (s *AppState) PromptDelete() {
+	deleteTextInput(&s.Prompt.Input, &s.Prompt.Cursor, &s.Prompt.SelectAll)
+}
+
+func (s *AppState) PromptCursorLeft() {
+	moveTextInputCursorLeft(&s.Prompt.Cursor, &s.Prompt.SelectAll)
+}
+
+func (s *AppState) PromptCursorRight() {
+	moveTextInputCursorRight(s.Prompt.Input, &s.Prompt.Cursor, &s.Prompt.SelectAll)
+}
+
+// PromptCursorHome moves to the start of the input, or of the current line in
+// a multi-line prompt.
+func (s *AppState) PromptCursorHome() {
+	if !s.Prompt.Multiline {
+		moveTextInputCursorHome(&s.Prompt.Cursor, &s.Prompt.SelectAll)
+		return
+	}
+	s.Prompt.SelectAll = false
+	_, col := s.PromptCursorLineCol()
+	s.Prompt.Cursor -= col
+}
+
+// PromptCursorEnd moves to the end of the input, or of the current line in a
+// multi-line prompt.
+func (s *AppState) PromptCursorEnd() {
+	if !s.Prompt.Multiline {
+		moveTextInputCursorEnd(s.Prompt.Input, &s.Prompt.Cursor, &s.Prompt.SelectAll)
+		return
+	}
+	s.Prompt.SelectAll = false
+	line, col := s.PromptCursorLineCol()
+	s.Prompt.Cursor += len([]rune(strings.Split(s.Prompt.Input, "\n")[line])) - col
+}
+
+func (s *AppState) PromptSelectAllText() {
+	selectAllTextInput(s.Prompt.Input, &s.Prompt.Cursor, &s.Prompt.SelectAll)
+}
+
+func (s AppState) SelectedPromptText() string {
+	if s.Prompt.SelectAll {
+		return s.Prompt.Input
+	}
+	return ""
+}
+
+func (s *AppState) DeletePromptSelection() {
+	clearSelectedText(&s.Prompt.Input, &s.Prompt.Cursor, &s.Prompt.SelectAll)
+}
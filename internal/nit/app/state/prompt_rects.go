@@ -0,0 +1,58 @@
+package state
+
+import "strings"
+
+// promptMaxInputRows caps how many lines a multi-line prompt shows before it
+// scrolls.
+const promptMaxInputRows = 10
+
+// PromptInputRows is how many lines the prompt input takes: one, or for a
+// multi-line prompt its lines, at least 3.
+func (s AppState) PromptInputRows() int {
+	if !s.Prompt.Multiline {
+		return 1
+	}
+	rows := max(3, strings.Count(s.Prompt.Input, "\n")+1)
+	return max(1, min(rows, promptMaxInputRows, max(12, s.Viewport.Height)-8))
+}
+
+func (s AppState) PromptPanelRect() (x, y, w, h int) {
+	totalW := max(40, s.Viewport.Width)
+	totalH := max(12, s.Viewport.Height)
+	w = min(56, totalW)
+	if s.Prompt.Multiline {
+		w = min(80, totalW)
+	}
+	h = 6 + s.PromptInputRows() // border, title, separator, label, input, hint, border
+	if s.Prompt.OptionLabel != "" {
+		h++
+	}
+	x = max(0, (totalW-w)/2)
+	y = max(0, (totalH-h)/2)
+	return x, y, w, h
+}
+
+// PromptOptionRect is the checkbox row; it is empty when the prompt has no
+// option.
+func (s AppState) PromptOptionRect() (x, y, w, h int) {
+	if s.Prompt.OptionLabel == "" {
+		return 0, 0, 0, 0
+	}
+	px, py, pw, _ := s.PromptPanelRect()
+	return px + 1, py + 4 + s.PromptInputRows(), pw - 2, 1
+}
+
+func (s *AppState) PromptClick(x, y int) bool {
+	if !s.Prompt.Open {
+		return false
+	}
+	if ox, oy, ow, oh := s.PromptOptionRect(); ow > 0 && x >= ox && x < ox+ow && y >= oy && y < oy+oh {
+		s.TogglePromptOption()
+		return true
+	}
+	px, py, pw, ph := s.PromptPanelRect()
+	if x < px || x >= px+pw || y < py || y >= py+ph {
+		s.ClosePrompt()
+	}
+	return true
+}
@@ -0,0 +1,135 @@
+package state
+
+import (
+	"strings"
+
+	"github.com/zGIKS/nit/internal/nit/app/actions"
+	"github.com/zGIKS/nit/internal/nit/git"
+)
+
+// OpenRebaseEditor starts an interactive rebase onto the commit selected in
+// the graph. The todo list is loaded separately.
+func (s *AppState) OpenRebaseEditor() {
+	if s.Focus != FocusGraph {
+		s.SetError("select the base commit in the graph")
+		return
+	}
+	c, ok := s.SelectedCommit()
+	if !ok {
+		s.SetError("no commit selected")
+		return
+	}
+	s.CloseMenu()
+	s.CloseBranchCreate()
+	s.Rebase = RebaseState{Open: true, Base: c.Hash, BaseShort: c.ShortHash}
+}
+
+func (s *AppState) CloseRebaseEditor() {
+	s.Rebase = RebaseState{}
+}
+
+// RebaseTodoTarget reports the base whose todo list still has to be loaded.
+func (s AppState) RebaseTodoTarget() (string, bool) {
+	r := s.Rebase
+	return r.Base, r.Open && !r.Loaded && !r.Loading
+}
+
+func (s *AppState) BeginRebaseTodoLoad() {
+	s.Rebase.Loading = true
+}
+
+func (s *AppState) SetRebaseTodo(base string, items []git.RebaseTodoItem) {
+	if !s.Rebase.Open || s.Rebase.Base != base {
+		return
+	}
+	s.Rebase.Items = items
+	s.Rebase.Loading = false
+	s.Rebase.Loaded = true
+	s.clampRebase()
+}
+
+func (s *AppState) MoveRebaseCursor(delta int) {
+	s.Rebase.Cursor += delta
+	s.clampRebase()
+}
+
+// MoveRebaseItem moves the item under the cursor up or down the todo list.
+func (s *AppState) MoveRebaseItem(delta int) {
+	items := s.Rebase.Items
+	i, j := s.Rebase.Cursor, s.Rebase.Cursor+delta
+	if i < 0 || i >= len(items) || j < 0 || j >= len(items) {
+		return
+	}
+	items[i], items[j] = items[j], items[i]
+	s.Rebase.Cursor = j
+	s.clampRebase()
+}
+
+func (s *AppState) SetRebaseAction(action git.RebaseAction) {
+	if s.Rebase.Cursor < 0 || s.Rebase.Cursor >= len(s.Rebase.Items) {
+		return
+	}
+	if action == git.RebaseReword {
+		it := s.Rebase.Items[s.Rebase.Cursor]
+		msg := it.Message
+		if msg == "" {
+			msg = it.FullMessage
+		}
+		if msg == "" {
+			msg = it.Subject
+		}
+		s.OpenPrompt(PromptState{
+			Kind:      PromptRebaseReword,
+			Target:    it.Hash,
+			Title:     "Reword " + it.ShortHash,
+			Label:     "Message: subject, blank line, body and trailers",
+			Input:     msg,
+			Multiline: true,
+		})
+		return
+	}
+	s.Rebase.Items[s.Rebase.Cursor].Action = action
+}
+
+func (s *AppState) setRebaseRewordMessage(hash, message string) {
+	if strings.TrimSpace(message) == "" {
+		return
+	}
+	for i := range s.Rebase.Items {
+		if s.Rebase.Items[i].Hash == hash {
+			s.Rebase.Items[i].Action = git.RebaseReword
+			s.Rebase.Items[i].Message = message
+			return
+		}
+	}
+}
+
+// StartRebase closes the editor and returns the operation running the todo
+// list, unless git would reject it.
+func (s *AppState) StartRebase() actions.ApplyResult {
+	r := s.Rebase
+	for _, it := range r.Items {
+		if it.Action == git.RebaseDrop {
+			continue
+		}
+		if it.Action == git.RebaseSquash || it.Action == git.RebaseFixup {
+			s.SetError("the first kept commit cannot be a " + string(it.Action))
+			return actions.ApplyResult{}
+		}
+		break
+	}
+	s.CloseRebaseEditor()
+	if len(r.Items) == 0 {
+		return actions.ApplyResult{}
+	}
+	return actions.ApplyResult{
+		Operations:         []actions.Operation{{Kind: actions.OpRebaseInteractive, Ref: r.Base, Todo: git.RebaseTodoScript(r.Items)}},
+		RefreshChanges:     true,
+		RefreshGraph:       true,
+		RefreshRepoSummary: true,
+	}
+}
+
+func (s *AppState) clampRebase() {
+	clampScrollView(len(s.Rebase.Items), &s.Rebase.Cursor, &s.Rebase.Offset, s.rebasePageSize())
+}
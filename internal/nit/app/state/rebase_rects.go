@@ -0,0 +1,32 @@
+package state
+
+func (s AppState) RebasePanelRect() (x, y, w, h int) {
+	totalW := max(40, s.Viewport.Width)
+	totalH := max(12, s.Viewport.Height)
+	x, y = 2, 1
+	w = totalW - 2*x
+	h = totalH - 2*y
+	return x, y, w, h
+}
+
+func (s AppState) rebasePageSize() int {
+	_, _, _, h := s.RebasePanelRect()
+	return max(1, h-2)
+}
+
+// RebaseClick selects the clicked todo item. Clicks outside the editor are
+// swallowed so edits are not lost by accident.
+func (s *AppState) RebaseClick(x, y int) bool {
+	if !s.Rebase.Open {
+		return false
+	}
+	px, py, pw, ph := s.RebasePanelRect()
+	if x < px || x >= px+pw {
+		return true
+	}
+	if idx, ok := boxContentLine(y, py, ph); ok && s.Rebase.Offset+idx < len(s.Rebase.Items) {
+		s.Rebase.Cursor = s.Rebase.Offset + idx
+	}
+	s.clampRebase()
+	return true
+}
@@ -0,0 +1,152 @@
+package state
+
+import (
+	"fmt"
+
+	"github.com/zGIKS/nit/internal/nit/app/actions"
+	"github.com/zGIKS/nit/internal/nit/git"
+)
+
+// resetModes are the modes offered by the reset modal, in order, with what
+// each one does to the changes after the target commit.
+var resetModes = []struct {
+	Mode git.ResetMode
+	Help string
+}{
+	{git.ResetSoft, "move the branch; keep the later changes staged"},
+	{git.ResetMixed, "move the branch; keep the later changes in your files, unstaged"},
+	{git.ResetHard, "move the branch and reset your files; uncommitted work is snapshotted"},
+}
+
+// OpenResetDialog offers to reset the current branch to the commit selected
+// in the graph. What a hard reset would discard is loaded separately.
+func (s *AppState) OpenResetDialog() {
+	if s.Focus != FocusGraph {
+		s.SetError("select the commit to reset to in the graph")
+		return
+	}
+	c, ok := s.SelectedCommit()
+	if !ok {
+		s.SetError("no commit selected")
+		return
+	}
+	s.CloseMenu()
+	s.CloseBranchCreate()
+	s.Reset = ResetState{Open: true, Hash: c.Hash, ShortHash: c.ShortHash, Subject: c.Subject, Cursor: 1}
+}
+
+func (s *AppState) CloseReset() {
+	s.Reset = ResetState{}
+}
+
+// ResetPreviewTarget reports the commit whose reset preview still has to be
+// loaded.
+func (s AppState) ResetPreviewTarget() (string, bool) {
+	r := s.Reset
+	return r.Hash, r.Open && !r.Loaded && !r.Loading
+}
+
+func (s *AppState) BeginResetPreviewLoad() {
+	s.Reset.Loading = true
+}
+
+func (s *AppState) SetResetPreview(hash string, preview git.ResetPreview) {
+	if !s.Reset.Open || s.Reset.Hash != hash {
+		return
+	}
+	s.Reset.Preview = preview
+	s.Reset.Loading = false
+	s.Reset.Loaded = true
+	if s.Reset.Confirm {
+		// A hard reset was chosen while this was loading.
+		s.ConfirmReset()
+	}
+}
+
+func (s *AppState) MoveResetCursor(delta int) {
+	if s.Reset.Confirm {
+		return
+	}
+	s.Reset.Cursor = max(0, min(len(resetModes)-1, s.Reset.Cursor+delta))
+}
+
+// BackReset stops waiting to confirm a hard reset, or closes the modal.
+func (s *AppState) BackReset() {
+	if s.Reset.Confirm {
+		s.Reset.Confirm = false
+		return
+	}
+	s.CloseReset()
+}
+
+// ConfirmReset runs the reset in the mode under the cursor. A hard reset
+// goes through the confirmation modal, which lists what it loses, so it
+// waits for its preview to be loaded first.
+func (s *AppState) ConfirmReset() actions.ApplyResult {
+	r := s.Reset
+	if !r.Open || r.Cursor < 0 || r.Cursor >= len(resetModes) {
+		return actions.ApplyResult{}
+	}
+	mode := resetModes[r.Cursor].Mode
+	if mode == git.ResetHard && !r.Loaded {
+		s.Reset.Confirm = true
+		return actions.ApplyResult{}
+	}
+	res := s.confirmDestructive(actions.ApplyResult{
+		Operations:         []actions.Operation{{Kind: actions.OpReset, Ref: r.Hash, ResetMode: string(mode)}},
+		RefreshChanges:     true,
+		RefreshGraph:       true,
+		RefreshRepoSummary: true,
+	})
+	s.CloseReset()
+	return res
+}
+
+// ResetTitle is the title of the reset modal.
+func (s AppState) ResetTitle() string {
+	return fmt.Sprintf("Reset %s to %s %s", s.BranchName, s.Reset.ShortHash, s.Reset.Subject)
+}
+
+// ResetLines are the rows of the reset modal and the row under the cursor,
+// -1 while waiting to confirm a hard reset.
+func (s AppState) ResetLines() (lines []string, cursor int) {
+	if s.Reset.Confirm {
+		return []string{"Checking what a hard reset would lose..."}, -1
+	}
+	for _, m := range resetModes {
+		lines = append(lines, fmt.Sprintf("%-6s %s", m.Mode, m.Help))
+	}
+	return lines, s.Reset.Cursor
+}
+
+func (s AppState) resetMaxHeight() int {
+	return max(12, s.Viewport.Height) - 2
+}
+
+func (s AppState) ResetPanelRect() (x, y, w, h int) {
+	totalW := max(40, s.Viewport.Width)
+	totalH := max(12, s.Viewport.Height)
+	lines, _ := s.ResetLines()
+	w = min(80, totalW)
+	h = min(len(lines)+2, s.resetMaxHeight())
+	x = max(0, (totalW-w)/2)
+	y = max(0, (totalH-h)/2)
+	return x, y, w, h
+}
+
+// ResetClick picks the clicked mode, or closes the modal on a click outside
+// of it.
+func (s *AppState) ResetClick(x, y int) bool {
+	if !s.Reset.Open {
+		return false
+	}
+	px, py, pw, ph := s.ResetPanelRect()
+	if x < px || x >= px+pw || y < py || y >= py+ph {
+		s.CloseReset()
+		return true
+	}
+	if idx, ok := boxContentLine(y, py, ph); ok && !s.Reset.Confirm && idx < len(resetModes) {
+		s.Reset.Cursor = idx
+	}
+	return true
+}
@@ -3,9 +3,14 @@ package state
 import "github.com/zGIKS/nit/internal/nit/git"
 
 func (s *AppState) rebuildChangesSlices() {
+	s.Changes.Conflicted = s.Changes.Conflicted[:0]
 	s.Changes.Staged = s.Changes.Staged[:0]
 	s.Changes.Unstaged = s.Changes.Unstaged[:0]
 	for _, e := range s.Changes.Entries {
+		if e.Conflicted {
+			s.Changes.Conflicted = append(s.Changes.Conflicted, e)
+			continue
+		}
 		if e.Staged {
 			s.Changes.Staged = append(s.Changes.Staged, e)
 		}
@@ -17,6 +22,17 @@ func (s *AppState) rebuildChangesSlices() {
 
 func (s *AppState) rebuildChangesRows() {
 	rows := make([]ChangeRow, 0, len(s.Changes.Entries)+4)
+	if len(s.Changes.Conflicted) > 0 {
+		rows = append(rows, ChangeRow{Text: "Merge Conflicts"})
+		for i, e := range s.Changes.Conflicted {
+			rows = append(rows, ChangeRow{
+				Text:       "  " + string([]byte{e.X, e.Y}) + " " + e.Path,
+				Selectable: true,
+				Section:    SectionConflicted,
+				EntryIndex: i,
+			})
+		}
+	}
 	if len(s.Changes.Staged) > 0 {
 		rows = append(rows, ChangeRow{Text: "Staged Changes"})
 		for i, e := range s.Changes.Staged {
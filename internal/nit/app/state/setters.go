@@ -17,7 +17,8 @@ func (s *AppState) SetViewport(width, height int) {
 }
 
 func (s *AppState) SetError(errMsg string) {
-	s.LastErr = errMsg
+	// The error is shown on one row; hook output often spans several.
+	s.LastErr = strings.ReplaceAll(errMsg, "\n", " ⏎ ")
 }
 
 func (s *AppState) SetRepoSummary(repo, branch string) {
@@ -25,6 +26,12 @@ func (s *AppState) SetRepoSummary(repo, branch string) {
 	setIfNotBlank(&s.BranchName, branch)
 }
 
+func (s *AppState) SetUpstreamStatus(upstream string, ahead, behind int) {
+	s.BranchUpstream = upstream
+	s.BranchAhead = ahead
+	s.BranchBehind = behind
+}
+
 func (s *AppState) SetTopBarLabels(repo, branch, fetch, menu string) {
 	setIfNotBlank(&s.RepoLabel, repo)
 	setIfNotBlank(&s.BranchLabel, branch)
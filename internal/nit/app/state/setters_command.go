@@ -1,6 +1,9 @@
 package state
 
+import "strings"
+
 func (s *AppState) AppendCommandText(text string) {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
 	appendTextInput(&s.Command.Input, &s.Command.Cursor, &s.Command.SelectAll, text)
 }
 
@@ -35,6 +38,7 @@ func (s *AppState) ExitCommandFocus() {
 	}
 	s.Focus = target
 	s.Command.SelectAll = false
+	s.cancelAmend()
 	s.clearCommandCommitOptions()
 	if s.Focus == FocusChanges {
 		s.snapChangesCursor(1)
@@ -62,6 +66,9 @@ func (s AppState) CommandClipboard() string {
 }
 
 func (s *AppState) AddCommandLog(cmd string) {
+	// Keep one row per command even when an argument, such as a commit
+	// message with a body, spans several lines.
+	cmd = strings.ReplaceAll(cmd, "\n", "⏎")
 	s.CommandLog = append(s.CommandLog, cmd)
 	if len(s.CommandLog) > 100 {
 		s.CommandLog = s.CommandLog[len(s.CommandLog)-100:]
@@ -86,6 +93,9 @@ func (s *AppState) PrepareCommandCommit(all, amend, signoff bool) {
 	}
 	s.Focus = FocusCommand
 	s.Command.SelectAll = false
+	if s.Command.CommitAmend && !amend {
+		s.cancelAmend()
+	}
 	s.Command.CommitAll = all
 	s.Command.CommitAmend = amend
 	s.Command.CommitSignoff = signoff
@@ -96,4 +106,6 @@ func (s *AppState) clearCommandCommitOptions() {
 	s.Command.CommitAll = false
 	s.Command.CommitAmend = false
 	s.Command.CommitSignoff = false
+	s.Command.CommitSign = s.Command.SignByDefault
+	s.clearAmendState()
 }
@@ -2,22 +2,28 @@ package state
 
 import "github.com/zGIKS/nit/internal/nit/git"
 
-func (s *AppState) SetGraph(lines []string) {
-	if len(lines) == 0 {
-		lines = []string{"No commits to display."}
+func (s *AppState) SetGraph(commits []git.Commit) {
+	s.Graph.Commits = commits
+	s.Graph.Lines = git.GraphLines(commits)
+	s.pruneGraphMarks()
+	if len(s.Graph.Lines) == 0 {
+		s.Graph.Lines = []string{"No commits to display."}
 	}
-	s.Graph.Lines = lines
 	if s.Graph.Cursor >= len(s.Graph.Lines) {
 		s.Graph.Cursor = max(0, len(s.Graph.Lines)-1)
 	}
 	s.Clamp()
 }
 
-func (s *AppState) SetBranches(lines []string) {
-	if len(lines) == 0 {
-		lines = []string{"No local branches."}
+func (s *AppState) SetBranches(entries []git.Branch) {
+	prev, hadPrev := s.SelectedBranchRow()
+	s.Branches.Entries = entries
+	s.rebuildBranchRows()
+	if hadPrev {
+		if idx := s.branchRowIndex(prev); idx >= 0 {
+			s.Branches.Cursor = idx
+		}
 	}
-	s.Branches.Lines = lines
 	if s.Branches.Cursor < 0 {
 		s.Branches.Cursor = 0
 	}
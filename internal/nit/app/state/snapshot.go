@@ -0,0 +1,66 @@
+package state
+
+import (
+	"fmt"
+
+	"github.com/zGIKS/nit/internal/nit/app/actions"
+	"github.com/zGIKS/nit/internal/nit/git"
+)
+
+// OpenSnapshotList asks for the discard snapshots of the repository, which
+// open in a dialog once loaded.
+func (s *AppState) OpenSnapshotList() {
+	s.CloseMenu()
+	s.Snapshots = SnapshotsState{Wanted: true}
+}
+
+// SnapshotsWanted reports whether the snapshots still have to be loaded.
+func (s AppState) SnapshotsWanted() bool {
+	return s.Snapshots.Wanted && !s.Snapshots.Loading
+}
+
+func (s *AppState) BeginSnapshotsLoad() {
+	s.Snapshots.Loading = true
+}
+
+func (s *AppState) SnapshotsFailed(err error) {
+	s.Snapshots = SnapshotsState{}
+	s.SetError(err.Error())
+}
+
+// SetSnapshots offers snapshots, newest first, in a dialog where choosing
+// one restores it.
+func (s *AppState) SetSnapshots(snaps []git.Snapshot) {
+	s.Snapshots = SnapshotsState{}
+	if len(snaps) == 0 {
+		s.SetError("no discard snapshots in this repository")
+		return
+	}
+	opts := make([]DialogOption, 0, len(snaps)+1)
+	for _, snap := range snaps {
+		opts = append(opts, DialogOption{Label: snapshotLabel(snap), Result: restoreSnapshotResult(snap.ID)})
+	}
+	opts = append(opts, DialogOption{Label: "Cancel"})
+	s.OpenDialog(DialogState{
+		Title:   "Discard snapshots",
+		Lines:   []string{"Restore what a discard threw away, newest first."},
+		Options: opts,
+	})
+}
+
+func snapshotLabel(snap git.Snapshot) string {
+	label := snap.Created.Local().Format("Jan 02 15:04") + "  " + snap.Label
+	if n := len(snap.Untracked); n > 0 {
+		label += fmt.Sprintf(" (%d untracked file(s))", n)
+	}
+	return label
+}
+
+// restoreSnapshotResult restores snapshot id, or the newest one when id is
+// empty.
+func restoreSnapshotResult(id string) actions.ApplyResult {
+	return actions.ApplyResult{
+		Operations:     []actions.Operation{{Kind: actions.OpRestoreSnapshot, Ref: id}},
+		RefreshChanges: true,
+	}
+}
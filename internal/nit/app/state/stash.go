@@ -0,0 +1,48 @@
+package state
+
+import (
+	"github.com/zGIKS/nit/internal/nit/app/actions"
+	"github.com/zGIKS/nit/internal/nit/git"
+)
+
+func (s *AppState) SetStashes(entries []git.Stash) {
+	s.Stash.Entries = entries
+	lines := make([]string, 0, len(entries))
+	for _, e := range entries {
+		lines = append(lines, e.Ref+" "+e.Message)
+	}
+	if len(lines) == 0 {
+		lines = []string{"No stashes."}
+	}
+	s.Stash.Lines = lines
+	clampScrollView(len(s.Stash.Lines), &s.Stash.Cursor, &s.Stash.Offset, s.stashPageSize())
+}
+
+func (s AppState) SelectedStash() (git.Stash, bool) {
+	if s.Stash.Cursor < 0 || s.Stash.Cursor >= len(s.Stash.Entries) {
+		return git.Stash{}, false
+	}
+	return s.Stash.Entries[s.Stash.Cursor], true
+}
+
+// stashOperation runs kind against the stash under the Stash cursor.
+func (s *AppState) stashOperation(kind actions.OpKind) []actions.Operation {
+	if s.Focus != FocusStash {
+		return nil
+	}
+	st, ok := s.SelectedStash()
+	if !ok {
+		s.SetError("no stash selected")
+		return nil
+	}
+	return []actions.Operation{{Kind: kind, Ref: st.Ref}}
+}
+
+func (s *AppState) OpenStashPrompt() {
+	s.OpenPrompt(PromptState{
+		Kind:        PromptStashPush,
+		Title:       "Stash changes",
+		Label:       "Message (optional)",
+		OptionLabel: "Include untracked files",
+	})
+}
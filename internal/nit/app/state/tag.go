@@ -0,0 +1,67 @@
+package state
+
+import (
+	"github.com/zGIKS/nit/internal/nit/app/actions"
+	"github.com/zGIKS/nit/internal/nit/git"
+)
+
+// selectedTagForAction returns the tag under the Branches cursor when that
+// panel has focus.
+func (s *AppState) selectedTagForAction() (string, bool) {
+	b, ok := s.SelectedBranch()
+	if s.Focus != FocusBranches || !ok || b.Kind != git.BranchTag {
+		s.SetError("no tag selected")
+		return "", false
+	}
+	return b.Name, true
+}
+
+// tagOperation runs kind against the tag under the Branches cursor.
+func (s *AppState) tagOperation(kind actions.OpKind) []actions.Operation {
+	tag, ok := s.selectedTagForAction()
+	if !ok {
+		return nil
+	}
+	return []actions.Operation{{Kind: kind, Ref: tag}}
+}
+
+// OpenTagCreatePrompt asks for a tag name. The tag goes on the selected
+// commit when the graph has focus and on HEAD otherwise.
+func (s *AppState) OpenTagCreatePrompt() {
+	ref, title := "", "Create tag at HEAD"
+	if s.Focus == FocusGraph {
+		if c, ok := s.SelectedCommit(); ok {
+			ref, title = c.Hash, "Create tag at "+c.ShortHash
+		}
+	}
+	s.OpenPrompt(PromptState{
+		Kind:        PromptTagCreate,
+		Target:      ref,
+		Title:       title,
+		Label:       "Tag name",
+		OptionLabel: "Annotated (asks for a message)",
+	})
+}
+
+func (s *AppState) openTagMessagePrompt(name, ref string) {
+	s.OpenPrompt(PromptState{
+		Kind:   PromptTagMessage,
+		Target: ref,
+		Value:  name,
+		Title:  "Annotate tag " + name,
+		Label:  "Message",
+	})
+}
+
+// OpenTagDeleteDialog asks to confirm deleting the selected tag.
+func (s *AppState) OpenTagDeleteDialog() {
+	tag, ok := s.selectedTagForAction()
+	if !ok {
+		return
+	}
+	// A tag delete is always held back for the confirmation modal.
+	s.confirmDestructive(actions.ApplyResult{
+		Operations:   []actions.Operation{{Kind: actions.OpTagDelete, Ref: tag}},
+		RefreshGraph: true,
+	})
+}
@@ -1,6 +1,7 @@
 package state
 
 import (
+	"github.com/zGIKS/nit/internal/nit/app/actions"
 	"github.com/zGIKS/nit/internal/nit/app/input"
 	"github.com/zGIKS/nit/internal/nit/git"
 )
@@ -15,11 +16,14 @@ const (
 	FocusGraph
 	FocusBranches
 	FocusCommandLog
+	FocusDiff
+	FocusStash
 )
 
 const (
-	SectionStaged   Section = "staged"
-	SectionUnstaged Section = "unstaged"
+	SectionStaged     Section = "staged"
+	SectionUnstaged   Section = "unstaged"
+	SectionConflicted Section = "conflicted"
 )
 
 type ChangeRow struct {
@@ -29,8 +33,11 @@ type ChangeRow struct {
 	EntryIndex int
 }
 
+// ChangesState lists the working tree changes. Conflicted files of an
+// unfinished merge are kept apart from the staged and unstaged ones.
 type ChangesState struct {
 	Entries       []git.ChangeEntry
+	Conflicted    []git.ChangeEntry
 	Staged        []git.ChangeEntry
 	Unstaged      []git.ChangeEntry
 	Rows          []ChangeRow
@@ -39,18 +46,242 @@ type ChangesState struct {
 	StickySection Section
 }
 
-type GraphState struct {
-	Lines  []string
+type DiffState struct {
+	Path   string
+	Staged bool
+	Loaded bool
+	File   git.FileDiff
+	Lines  []git.DiffLine
+	Marked map[int]bool
 	Cursor int
 	Offset int
 }
 
+// GraphState holds one display line per commit. Lines holds a single
+// placeholder message while Commits is empty. Marked holds the hashes of the
+// commits marked for a cherry-pick.
+type GraphState struct {
+	Commits []git.Commit
+	Lines   []string
+	Marked  map[string]bool
+	Cursor  int
+	Offset  int
+}
+
+// CommitDetailState backs the commit detail view opened from the graph. Diff
+// holds the diff of the file under FileCursor.
+type CommitDetailState struct {
+	Open       bool
+	Hash       string
+	Title      string
+	Loaded     bool
+	Detail     git.CommitDetail
+	FileCursor int
+	FileOffset int
+	DiffFocus  bool
+	Diff       DiffState
+	Loading    bool
+}
+
+type StashState struct {
+	Entries []git.Stash
+	Lines   []string
+	Cursor  int
+	Offset  int
+}
+
+type PromptKind string
+
+const (
+	PromptStashPush      PromptKind = "stash_push"
+	PromptBranchRename   PromptKind = "branch_rename"
+	PromptBranchUpstream PromptKind = "branch_upstream"
+	PromptBranchTrack    PromptKind = "branch_track"
+	PromptTagCreate      PromptKind = "tag_create"
+	PromptTagMessage     PromptKind = "tag_message"
+	PromptRebaseReword   PromptKind = "rebase_reword"
+	PromptCommitTrailer  PromptKind = "commit_trailer"
+	PromptCommitScope    PromptKind = "commit_scope"
+)
+
+// PromptState backs the prompt modal, a single line unless Multiline is set.
+// Kind decides what Submit does with the input and Target names what it
+// applies to, such as a branch. Value carries the answer of an earlier step of
+// a multi-step prompt. OptionLabel, when set, adds a checkbox toggled with Tab.
+type PromptState struct {
+	Open        bool
+	Kind        PromptKind
+	Target      string
+	Value       string
+	Title       string
+	Label       string
+	Input       string
+	Cursor      int
+	SelectAll   bool
+	OptionLabel string
+	Option      bool
+	Multiline   bool
+}
+
+// RebaseState backs the interactive rebase todo editor. Items are the
+// commits after Base, oldest first, each with the action to apply.
+type RebaseState struct {
+	Open      bool
+	Base      string
+	BaseShort string
+	Loading   bool
+	Loaded    bool
+	Items     []git.RebaseTodoItem
+	Cursor    int
+	Offset    int
+}
+
+// ConflictState backs the conflict view of one conflicted file. Choices
+// holds the side picked for each conflict block of File, and Hunk is the
+// block under the cursor.
+type ConflictState struct {
+	Open    bool
+	Path    string
+	Loading bool
+	Loaded  bool
+	File    git.ConflictFile
+	Choices []git.ConflictChoice
+	Hunk    int
+	Offset  int
+}
+
+// ResetState backs the reset modal opened on a graph commit. Cursor is the
+// mode under the cursor. Confirm is set when a hard reset is chosen before
+// Preview, which lists what it would lose, is loaded.
+type ResetState struct {
+	Open      bool
+	Hash      string
+	ShortHash string
+	Subject   string
+	Cursor    int
+	Confirm   bool
+	Loading   bool
+	Loaded    bool
+	Preview   git.ResetPreview
+}
+
+// OpOutputState follows a long-running operation such as a commit or a
+// fetch: its output, its latest progress report and whether it is still
+// running. The modal showing the output only opens when hooks are installed.
+type OpOutputState struct {
+	Open       bool
+	ID         int
+	Op         actions.Operation
+	Hooks      []string
+	Lines      []string
+	Progress   git.Progress
+	Running    bool
+	Cancelling bool
+	Failed     bool
+	Offset     int
+}
+
+// DialogOption is one choice of a dialog. Besides its Result, choosing it
+// can open a prompt, apply Action or open the details of Commit.
+type DialogOption struct {
+	Label  string
+	Result actions.ApplyResult
+	Prompt PromptState
+	Action actions.Action
+	Commit git.Commit
+}
+
+type DialogState struct {
+	Open    bool
+	Title   string
+	Lines   []string
+	Options []DialogOption
+	Cursor  int
+	Offset  int
+}
+
+// ConfirmState backs the confirmation modal every destructive operation goes
+// through. Lines explain the operation and Items list what it loses; Result
+// runs once it is confirmed. With TypeYes, "yes" has to be typed into Input
+// first. Branch is set for a force delete, whose Items are the commits only
+// that branch has and are loaded separately.
+type ConfirmState struct {
+	Open      bool
+	Title     string
+	Label     string
+	Lines     []string
+	Items     []string
+	Note      string
+	Result    actions.ApplyResult
+	Choice    int
+	TypeYes   bool
+	Input     string
+	Cursor    int
+	SelectAll bool
+	Branch    string
+	Loading   bool
+	Loaded    bool
+}
+
+// FileHistoryState asks for the commits that changed Path to be loaded; they
+// are offered in a dialog once they are.
+type FileHistoryState struct {
+	Path    string
+	Loading bool
+}
+
+// EditorRequest asks for a file to be opened at Line, or the commit message
+// to be edited, in the user's editor, which takes over the terminal until it
+// exits.
+type EditorRequest struct {
+	Path          string
+	Line          int
+	CommitMessage bool
+	Message       string
+}
+
+// SnapshotsState asks for the discard snapshots of the repository to be
+// loaded; they are offered in a dialog once they are.
+type SnapshotsState struct {
+	Wanted  bool
+	Loading bool
+}
+
+type BranchRowKind int
+
+const (
+	BranchRowRef BranchRowKind = iota
+	BranchRowSection
+	BranchRowNote
+)
+
+// BranchRow is one row of the Branches panel: a ref, the header of the
+// collapsible Remotes or Tags section, or a placeholder note.
+type BranchRow struct {
+	Kind    BranchRowKind
+	Section git.BranchKind
+	Ref     git.Branch
+}
+
 type BranchesState struct {
-	Lines  []string
-	Cursor int
-	Offset int
+	Entries     []git.Branch
+	Rows        []BranchRow
+	Lines       []string
+	RemotesOpen bool
+	TagsOpen    bool
+	Cursor      int
+	Offset      int
 }
 
+// CommandState backs the commit editor. Template is the cleaned
+// commit.template, filled in whenever the editor gains focus while empty;
+// WasFocused remembers the focus seen by the last SyncCommitTemplate.
+// Conventional, Types and Trailers come from the [commit] config section.
+// While amending, AmendHash is the commit being rewritten, AmendPushedTo the
+// upstream that already has it, if any, and AmendDraft the message typed
+// before HEAD's message replaced it. CommitSign starts from
+// commit.gpgsign (SignByDefault) and SignFormat is gpg.format; a commit
+// passes a signing flag only when the two differ.
 type CommandState struct {
 	Input         string
 	Cursor        int
@@ -60,6 +291,18 @@ type CommandState struct {
 	CommitAll     bool
 	CommitAmend   bool
 	CommitSignoff bool
+	CommitSign    bool
+	SignByDefault bool
+	SignFormat    string
+	AmendHash     string
+	AmendPushedTo string
+	AmendDraft    string
+	AmendLoading  bool
+	Template      string
+	WasFocused    bool
+	Conventional  bool
+	Types         []string
+	Trailers      []string
 }
 
 type CommandLogState struct {
@@ -76,8 +319,23 @@ type AppState struct {
 	Focus                    FocusState
 	Command                  CommandState
 	Changes                  ChangesState
+	Diff                     DiffState
 	Graph                    GraphState
+	CommitDetail             CommitDetailState
 	Branches                 BranchesState
+	Stash                    StashState
+	Prompt                   PromptState
+	Dialog                   DialogState
+	Confirm                  ConfirmState
+	ConfirmTypeYes           bool
+	Snapshots                SnapshotsState
+	FileHistory              FileHistoryState
+	Editor                   EditorRequest
+	CopyRequest              string
+	Rebase                   RebaseState
+	Conflict                 ConflictState
+	Reset                    ResetState
+	OpOutput                 OpOutputState
 	CommandLogView           CommandLogState
 	CommandLog               []string
 	Viewport                 Viewport
@@ -95,6 +353,12 @@ type AppState struct {
 	HoverBranch              bool
 	RepoName                 string
 	BranchName               string
+	BranchUpstream           string
+	BranchAhead              int
+	BranchBehind             int
+	RepoState                git.RepoState
+	RepoStep                 int
+	RepoSteps                int
 	RepoLabel                string
 	BranchLabel              string
 	RepoBranchSeparator      string
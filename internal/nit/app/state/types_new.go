@@ -11,9 +11,15 @@ func New(keys input.Keymap) AppState {
 		Changes: ChangesState{
 			StickySection: SectionUnstaged,
 		},
+		Graph: GraphState{
+			Lines: []string{"Loading graph..."},
+		},
 		Branches: BranchesState{
 			Lines: []string{"Loading branches..."},
 		},
+		Stash: StashState{
+			Lines: []string{"Loading stashes..."},
+		},
 		Keys:                     keys,
 		MenuHoverIndex:           -1,
 		MenuOffset:               0,
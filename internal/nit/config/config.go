@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 	"runtime"
 	"strings"
+	"time"
 )
 
 var errConfigNotExist = errors.New("config file does not exist")
@@ -49,18 +50,35 @@ func Load() (AppConfig, string) {
 			MenuLeft:  KeyBinding{Keys: []string{"left", "h"}},
 		},
 		CommitEditorKeys: CommitEditorKeyConfig{
-			Submit:    KeyBinding{Keys: []string{"enter"}},
-			Cancel:    KeyBinding{Keys: []string{"esc"}},
-			Copy:      KeyBinding{Keys: []string{"ctrl+c"}},
-			Cut:       KeyBinding{Keys: []string{"ctrl+x"}},
-			Paste:     KeyBinding{Keys: []string{"ctrl+v"}},
-			SelectAll: KeyBinding{Keys: []string{"ctrl+a"}},
-			Backspace: KeyBinding{Keys: []string{"backspace"}},
-			Delete:    KeyBinding{Keys: []string{"delete"}},
-			Left:      KeyBinding{Keys: []string{"left"}},
-			Right:     KeyBinding{Keys: []string{"right"}},
-			Home:      KeyBinding{Keys: []string{"home"}},
-			End:       KeyBinding{Keys: []string{"end", "ctrl+e"}},
+			Submit:         KeyBinding{Keys: []string{"enter"}},
+			Cancel:         KeyBinding{Keys: []string{"esc"}},
+			Copy:           KeyBinding{Keys: []string{"ctrl+c"}},
+			Cut:            KeyBinding{Keys: []string{"ctrl+x"}},
+			Paste:          KeyBinding{Keys: []string{"ctrl+v"}},
+			SelectAll:      KeyBinding{Keys: []string{"ctrl+a"}},
+			Backspace:      KeyBinding{Keys: []string{"backspace"}},
+			Delete:         KeyBinding{Keys: []string{"delete"}},
+			Left:           KeyBinding{Keys: []string{"left"}},
+			Right:          KeyBinding{Keys: []string{"right"}},
+			Home:           KeyBinding{Keys: []string{"home"}},
+			End:            KeyBinding{Keys: []string{"end", "ctrl+e"}},
+			Up:             KeyBinding{Keys: []string{"up"}},
+			Down:           KeyBinding{Keys: []string{"down"}},
+			Newline:        KeyBinding{Keys: []string{"ctrl+j", "alt+enter"}},
+			WrapBody:       KeyBinding{Keys: []string{"alt+q"}},
+			Trailer:        KeyBinding{Keys: []string{"ctrl+t"}},
+			CommitType:     KeyBinding{Keys: []string{"ctrl+o"}},
+			Amend:          KeyBinding{Keys: []string{"alt+a"}},
+			Sign:           KeyBinding{Keys: []string{"alt+s"}},
+			ExternalEditor: KeyBinding{Keys: []string{"alt+e"}},
+		},
+		Commit: CommitConfig{
+			Types:    []string{"feat", "fix", "docs", "style", "refactor", "perf", "test", "build", "ci", "chore", "revert"},
+			Trailers: []string{"Co-authored-by", "Refs", "Fixes", "Reviewed-by"},
+		},
+		Git: GitConfig{
+			ReadTimeout:  4 * time.Second,
+			WriteTimeout: 4 * time.Second,
 		},
 		UI: UIConfig{
 			RepoLabel:                "repo",
@@ -105,6 +123,9 @@ func applyEnvOverrides(cfg *AppConfig) string {
 	if v := strings.TrimSpace(os.Getenv("NIT_CLIPBOARD_PASTE_CMD")); v != "" {
 		cfg.Clipboard.PasteCmd = v
 	}
+	if v := strings.TrimSpace(os.Getenv("NIT_GIT_PATH")); v != "" {
+		cfg.Git.Path = v
+	}
 	return applyModeFromEnv(cfg)
 }
 
@@ -3,7 +3,9 @@ package config
 import (
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/BurntSushi/toml"
 )
@@ -36,7 +38,21 @@ func loadFromTOML(cfg *AppConfig) string {
 	}
 	mergeCommitEditorKeys(&cfg.CommitEditorKeys, fileCfg.Keys.CommitEditor)
 	mergeUIConfig(&cfg.UI, fileCfg.UI)
-	return modeWarn
+	mergeCommitConfig(&cfg.Commit, fileCfg.Commit)
+	cfg.Confirm = fileCfg.Confirm
+	remoteWarn := mergeRemoteConfig(&cfg.Remote, fileCfg.Remote)
+	gitWarn := mergeGitConfig(&cfg.Git, fileCfg.Git)
+	return joinWarnings(modeWarn, remoteWarn, gitWarn)
+}
+
+func joinWarnings(warns ...string) string {
+	kept := make([]string, 0, len(warns))
+	for _, w := range warns {
+		if w != "" {
+			kept = append(kept, w)
+		}
+	}
+	return strings.Join(kept, "; ")
 }
 
 // mergeStr overwrites dst with src if src is non-empty after trimming.
@@ -62,6 +78,74 @@ func mergeUIConfig(dst *UIConfig, src UIConfig) {
 	mergeStr(&dst.BranchCreateSourceLabel, src.BranchCreateSourceLabel)
 }
 
+func mergeCommitConfig(dst *CommitConfig, src CommitConfig) {
+	dst.Conventional = src.Conventional
+	dst.VerifySignatures = src.VerifySignatures
+	if len(src.Types) > 0 {
+		dst.Types = src.Types
+	}
+	if len(src.Trailers) > 0 {
+		dst.Trailers = src.Trailers
+	}
+}
+
+func mergeRemoteConfig(dst *RemoteConfig, src RemoteFileConfig) string {
+	return joinWarnings(
+		mergeDuration(&dst.FetchTimeout, src.FetchTimeout, "remote.fetch_timeout"),
+		mergeDuration(&dst.PullTimeout, src.PullTimeout, "remote.pull_timeout"),
+		mergeDuration(&dst.PushTimeout, src.PushTimeout, "remote.push_timeout"),
+	)
+}
+
+func mergeGitConfig(dst *GitConfig, src GitFileConfig) string {
+	mergeStr(&dst.Path, src.Path)
+	warns := []string{
+		mergeDuration(&dst.ReadTimeout, src.ReadTimeout, "git.read_timeout"),
+		mergeDuration(&dst.WriteTimeout, src.WriteTimeout, "git.write_timeout"),
+	}
+	for _, raw := range src.Options {
+		opt := strings.TrimSpace(raw)
+		if key, _, ok := strings.Cut(opt, "="); !ok || strings.TrimSpace(key) == "" {
+			warns = append(warns, fmt.Sprintf("invalid git.options entry %q, expected key=value", raw))
+			continue
+		}
+		dst.Options = append(dst.Options, opt)
+	}
+	keys := make([]string, 0, len(src.Env))
+	for k := range src.Env {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		name := strings.TrimSpace(k)
+		if name == "" || strings.Contains(name, "=") {
+			warns = append(warns, fmt.Sprintf("invalid git.env name %q", k))
+			continue
+		}
+		dst.Env = append(dst.Env, name+"="+src.Env[k])
+	}
+	return joinWarnings(warns...)
+}
+
+// mergeDuration overwrites dst with src parsed as a duration, and warns
+// instead when src is not a valid, non-negative duration.
+func mergeDuration(dst *time.Duration, src, name string) string {
+	raw := strings.TrimSpace(src)
+	if raw == "" {
+		return ""
+	}
+	if raw == "0" {
+		*dst = 0
+		return ""
+	}
+	d, err := time.ParseDuration(raw)
+	if err != nil || d < 0 {
+		return fmt.Sprintf("invalid %s %q, using %s", name, raw, *dst)
+	}
+	*dst = d
+	return ""
+}
+
 func mergeCommitEditorKeys(dst *CommitEditorKeyConfig, src CommitEditorKeyConfig) {
 	mergeKey := func(dstBinding *KeyBinding, srcBinding KeyBinding) {
 		if len(srcBinding.Keys) > 0 {
@@ -80,6 +164,15 @@ func mergeCommitEditorKeys(dst *CommitEditorKeyConfig, src CommitEditorKeyConfig
 	mergeKey(&dst.Right, src.Right)
 	mergeKey(&dst.Home, src.Home)
 	mergeKey(&dst.End, src.End)
+	mergeKey(&dst.Up, src.Up)
+	mergeKey(&dst.Down, src.Down)
+	mergeKey(&dst.Newline, src.Newline)
+	mergeKey(&dst.WrapBody, src.WrapBody)
+	mergeKey(&dst.Trailer, src.Trailer)
+	mergeKey(&dst.CommitType, src.CommitType)
+	mergeKey(&dst.Amend, src.Amend)
+	mergeKey(&dst.Sign, src.Sign)
+	mergeKey(&dst.ExternalEditor, src.ExternalEditor)
 }
 
 func normalizeClipboardMode(raw string) (ClipboardMode, string) {
@@ -1,5 +1,7 @@
 package config
 
+import "time"
+
 type ClipboardMode string
 
 const (
@@ -15,34 +17,79 @@ type KeyBinding struct {
 }
 
 type KeyConfig struct {
-	Quit         KeyBinding            `toml:"quit"`
-	TogglePanel  KeyBinding            `toml:"toggle_panel"`
-	FocusCommand KeyBinding            `toml:"focus_command"`
-	Down         KeyBinding            `toml:"down"`
-	Up           KeyBinding            `toml:"up"`
-	ToggleOne    KeyBinding            `toml:"toggle_one"`
-	StageAll     KeyBinding            `toml:"stage_all"`
-	UnstageAll   KeyBinding            `toml:"unstage_all"`
-	Fetch        KeyBinding            `toml:"fetch"`
-	Push         KeyBinding            `toml:"push"`
-	MenuRight    KeyBinding            `toml:"menu_right"`
-	MenuLeft     KeyBinding            `toml:"menu_left"`
-	CommitEditor CommitEditorKeyConfig `toml:"commit_editor"`
+	Quit                KeyBinding            `toml:"quit"`
+	TogglePanel         KeyBinding            `toml:"toggle_panel"`
+	FocusCommand        KeyBinding            `toml:"focus_command"`
+	Down                KeyBinding            `toml:"down"`
+	Up                  KeyBinding            `toml:"up"`
+	ToggleOne           KeyBinding            `toml:"toggle_one"`
+	StageAll            KeyBinding            `toml:"stage_all"`
+	UnstageAll          KeyBinding            `toml:"unstage_all"`
+	Fetch               KeyBinding            `toml:"fetch"`
+	Push                KeyBinding            `toml:"push"`
+	MenuRight           KeyBinding            `toml:"menu_right"`
+	MenuLeft            KeyBinding            `toml:"menu_left"`
+	ToggleLine          KeyBinding            `toml:"toggle_line"`
+	DiscardSelection    KeyBinding            `toml:"discard_selection"`
+	StashPush           KeyBinding            `toml:"stash_push"`
+	StashApply          KeyBinding            `toml:"stash_apply"`
+	StashPop            KeyBinding            `toml:"stash_pop"`
+	StashDrop           KeyBinding            `toml:"stash_drop"`
+	BranchDelete        KeyBinding            `toml:"branch_delete"`
+	BranchRename        KeyBinding            `toml:"branch_rename"`
+	BranchUpstream      KeyBinding            `toml:"branch_upstream"`
+	BranchUnsetUpstream KeyBinding            `toml:"branch_unset_upstream"`
+	BranchDeleteRemote  KeyBinding            `toml:"branch_delete_remote"`
+	TagCreate           KeyBinding            `toml:"tag_create"`
+	TagPush             KeyBinding            `toml:"tag_push"`
+	RebaseInteractive   KeyBinding            `toml:"rebase_interactive"`
+	RebaseContinue      KeyBinding            `toml:"rebase_continue"`
+	RebaseSkip          KeyBinding            `toml:"rebase_skip"`
+	RebaseAbort         KeyBinding            `toml:"rebase_abort"`
+	CommitAmend         KeyBinding            `toml:"commit_amend"`
+	CancelOperation     KeyBinding            `toml:"cancel_operation"`
+	Merge               KeyBinding            `toml:"merge"`
+	MergeContinue       KeyBinding            `toml:"merge_continue"`
+	MergeAbort          KeyBinding            `toml:"merge_abort"`
+	ContinueOperation   KeyBinding            `toml:"continue_operation"`
+	SkipOperation       KeyBinding            `toml:"skip_operation"`
+	AbortOperation      KeyBinding            `toml:"abort_operation"`
+	CherryPick          KeyBinding            `toml:"cherry_pick"`
+	Revert              KeyBinding            `toml:"revert"`
+	Reset               KeyBinding            `toml:"reset"`
+	UndoDiscard         KeyBinding            `toml:"undo_discard"`
+	DiscardSnapshots    KeyBinding            `toml:"discard_snapshots"`
+	FileMenu            KeyBinding            `toml:"file_menu"`
+	OpenInEditor        KeyBinding            `toml:"open_in_editor"`
+	CopyPath            KeyBinding            `toml:"copy_path"`
+	FileHistory         KeyBinding            `toml:"file_history"`
+	MarkDown            KeyBinding            `toml:"mark_down"`
+	MarkUp              KeyBinding            `toml:"mark_up"`
+	CommitEditor        CommitEditorKeyConfig `toml:"commit_editor"`
 }
 
 type CommitEditorKeyConfig struct {
-	Submit    KeyBinding `toml:"submit"`
-	Cancel    KeyBinding `toml:"cancel"`
-	Copy      KeyBinding `toml:"copy"`
-	Cut       KeyBinding `toml:"cut"`
-	Paste     KeyBinding `toml:"paste"`
-	SelectAll KeyBinding `toml:"select_all"`
-	Backspace KeyBinding `toml:"backspace"`
-	Delete    KeyBinding `toml:"delete"`
-	Left      KeyBinding `toml:"left"`
-	Right     KeyBinding `toml:"right"`
-	Home      KeyBinding `toml:"home"`
-	End       KeyBinding `toml:"end"`
+	Submit         KeyBinding `toml:"submit"`
+	Cancel         KeyBinding `toml:"cancel"`
+	Copy           KeyBinding `toml:"copy"`
+	Cut            KeyBinding `toml:"cut"`
+	Paste          KeyBinding `toml:"paste"`
+	SelectAll      KeyBinding `toml:"select_all"`
+	Backspace      KeyBinding `toml:"backspace"`
+	Delete         KeyBinding `toml:"delete"`
+	Left           KeyBinding `toml:"left"`
+	Right          KeyBinding `toml:"right"`
+	Home           KeyBinding `toml:"home"`
+	End            KeyBinding `toml:"end"`
+	Up             KeyBinding `toml:"up"`
+	Down           KeyBinding `toml:"down"`
+	Newline        KeyBinding `toml:"newline"`
+	WrapBody       KeyBinding `toml:"wrap_body"`
+	Trailer        KeyBinding `toml:"trailer"`
+	CommitType     KeyBinding `toml:"commit_type"`
+	Amend          KeyBinding `toml:"amend"`
+	Sign           KeyBinding `toml:"sign"`
+	ExternalEditor KeyBinding `toml:"external_editor"`
 }
 
 type ClipboardConfig struct {
@@ -67,10 +114,73 @@ type UIConfig struct {
 	BranchCreateSourceLabel  string `toml:"branch_create_source_label"`
 }
 
+// CommitConfig controls the commit editor. Conventional turns on the
+// Conventional Commits type picker and subject check; Types lists the allowed
+// types and Trailers the keys offered by the trailer picker. VerifySignatures
+// checks every signature in the graph, which runs the signing program once
+// per signed commit.
+type CommitConfig struct {
+	Conventional     bool     `toml:"conventional"`
+	Types            []string `toml:"types"`
+	Trailers         []string `toml:"trailers"`
+	VerifySignatures bool     `toml:"verify_signatures"`
+}
+
+// ConfirmConfig controls the confirmation asked before destructive
+// operations. TypeYes makes the hardest to undo, such as discarding all
+// changes or a hard reset, ask for "yes" to be typed instead of a choice.
+type ConfirmConfig struct {
+	TypeYes bool `toml:"type_yes"`
+}
+
+// RemoteConfig limits how long fetch, pull and push may run before they are
+// stopped. Zero, the default for all three, means no limit; pull and push run
+// hooks, which may take as long as they need.
+type RemoteConfig struct {
+	FetchTimeout time.Duration
+	PullTimeout  time.Duration
+	PushTimeout  time.Duration
+}
+
+// RemoteFileConfig is the [remote] section as written in the file. Timeouts
+// are Go durations such as "90s" or "5m"; "0" turns the limit off.
+type RemoteFileConfig struct {
+	FetchTimeout string `toml:"fetch_timeout"`
+	PullTimeout  string `toml:"pull_timeout"`
+	PushTimeout  string `toml:"push_timeout"`
+}
+
+// GitConfig controls how nit runs git. ReadTimeout limits commands that only
+// read the repository and WriteTimeout the ones that change it; zero means no
+// limit. Path may point at a git binary or a wrapper script, Options are
+// "key=value" settings passed with -c to every command, and Env holds
+// KEY=VALUE pairs added to git's environment.
+type GitConfig struct {
+	ReadTimeout  time.Duration
+	WriteTimeout time.Duration
+	Path         string
+	Options      []string
+	Env          []string
+}
+
+// GitFileConfig is the [git] section as written in the file. Timeouts are Go
+// durations like the ones in [remote]; env is a table of variables.
+type GitFileConfig struct {
+	Path         string            `toml:"path"`
+	ReadTimeout  string            `toml:"read_timeout"`
+	WriteTimeout string            `toml:"write_timeout"`
+	Options      []string          `toml:"options"`
+	Env          map[string]string `toml:"env"`
+}
+
 type FileConfig struct {
-	Clipboard ClipboardConfig `toml:"clipboard"`
-	Keys      KeyConfig       `toml:"keys"`
-	UI        UIConfig        `toml:"ui"`
+	Clipboard ClipboardConfig  `toml:"clipboard"`
+	Keys      KeyConfig        `toml:"keys"`
+	UI        UIConfig         `toml:"ui"`
+	Commit    CommitConfig     `toml:"commit"`
+	Confirm   ConfirmConfig    `toml:"confirm"`
+	Remote    RemoteFileConfig `toml:"remote"`
+	Git       GitFileConfig    `toml:"git"`
 }
 
 type AppConfig struct {
@@ -79,4 +189,8 @@ type AppConfig struct {
 	Keys             KeyConfig
 	CommitEditorKeys CommitEditorKeyConfig
 	UI               UIConfig
+	Commit           CommitConfig
+	Confirm          ConfirmConfig
+	Remote           RemoteConfig
+	Git              GitConfig
 }
@@ -1,6 +1,7 @@
 package cmds
 
 import (
+	"fmt"
 	"os"
 	"strconv"
 	"strings"
@@ -55,22 +56,170 @@ func LoadChangesCmd(svc g.Service) tea.Cmd {
 
 func LoadGraphCmd(svc g.Service) tea.Cmd {
 	return func() tea.Msg {
-		lines, err := svc.LoadGraph()
-		return common.GraphLoadedMsg{Lines: lines, Err: err}
+		commits, err := svc.LoadGraph()
+		return common.GraphLoadedMsg{Commits: commits, Err: err}
+	}
+}
+
+func LoadDiffCmd(svc g.Service, path string, staged bool) tea.Cmd {
+	return func() tea.Msg {
+		diff, err := svc.LoadDiff(path, staged)
+		diff.Path = path
+		diff.Staged = staged
+		return common.DiffLoadedMsg{Diff: diff, Err: err}
+	}
+}
+
+func LoadCommitDetailCmd(svc g.Service, hash string) tea.Cmd {
+	return func() tea.Msg {
+		detail, err := svc.LoadCommitDetail(hash)
+		detail.Hash = hash
+		return common.CommitDetailLoadedMsg{Detail: detail, Err: err}
+	}
+}
+
+func LoadCommitFileDiffCmd(svc g.Service, detail g.CommitDetail, file g.CommitFile) tea.Cmd {
+	return func() tea.Msg {
+		diff, err := svc.LoadCommitFileDiff(detail, file)
+		diff.Path = file.Path
+		return common.CommitFileDiffLoadedMsg{Hash: detail.Hash, Diff: diff, Err: err}
+	}
+}
+
+func LoadStashesCmd(svc g.Service) tea.Cmd {
+	return func() tea.Msg {
+		stashes, err := svc.LoadStashes()
+		return common.StashesLoadedMsg{Stashes: stashes, Err: err}
 	}
 }
 
 func LoadBranchesCmd(svc g.Service) tea.Cmd {
 	return func() tea.Msg {
-		lines, err := svc.LoadBranches()
-		return common.BranchesLoadedMsg{Lines: lines, Err: err}
+		branches, err := svc.LoadBranches()
+		return common.BranchesLoadedMsg{Branches: branches, Err: err}
 	}
 }
 
 func LoadRepoSummaryCmd(svc g.Service) tea.Cmd {
 	return func() tea.Msg {
-		repo, branch, err := svc.LoadRepoSummary()
-		return common.RepoSummaryLoadedMsg{Repo: repo, Branch: branch, Err: err}
+		summary, err := svc.LoadRepoSummary()
+		return common.RepoSummaryLoadedMsg{Summary: summary, Err: err}
+	}
+}
+
+func LoadCommitTemplateCmd(svc g.Service) tea.Cmd {
+	return func() tea.Msg {
+		template, err := svc.LoadCommitTemplate()
+		return common.CommitTemplateLoadedMsg{Template: template, Err: err}
+	}
+}
+
+func LoadSigningConfigCmd(svc g.Service) tea.Cmd {
+	return func() tea.Msg {
+		cfg, err := svc.LoadSigningConfig()
+		return common.SigningConfigLoadedMsg{Config: cfg, Err: err}
+	}
+}
+
+func LoadAmendCommitCmd(svc g.Service) tea.Cmd {
+	return func() tea.Msg {
+		commit, err := svc.LoadCommitHeader("HEAD")
+		if err != nil {
+			return common.AmendCommitLoadedMsg{Err: err}
+		}
+		pushedTo, err := svc.HeadPushedTo()
+		return common.AmendCommitLoadedMsg{Commit: commit, PushedTo: pushedTo, Err: err}
+	}
+}
+
+func LoadRebaseTodoCmd(svc g.Service, base string) tea.Cmd {
+	return func() tea.Msg {
+		items, err := svc.LoadRebaseTodo(base)
+		return common.RebaseTodoLoadedMsg{Base: base, Items: items, Err: err}
+	}
+}
+
+func LoadConflictCmd(svc g.Service, path string) tea.Cmd {
+	return func() tea.Msg {
+		file, err := svc.LoadConflict(path)
+		file.Path = path
+		return common.ConflictLoadedMsg{File: file, Err: err}
+	}
+}
+
+func LoadResetPreviewCmd(svc g.Service, hash string) tea.Cmd {
+	return func() tea.Msg {
+		preview, err := svc.LoadResetPreview(hash)
+		return common.ResetPreviewLoadedMsg{Hash: hash, Preview: preview, Err: err}
+	}
+}
+
+func LoadUnmergedCommitsCmd(svc g.Service, branch string) tea.Cmd {
+	return func() tea.Msg {
+		commits, err := svc.LoadUnmergedCommits(branch)
+		return common.UnmergedCommitsLoadedMsg{Branch: branch, Commits: commits, Err: err}
+	}
+}
+
+func LoadFileHistoryCmd(svc g.Service, path string) tea.Cmd {
+	return func() tea.Msg {
+		commits, err := svc.LoadFileHistory(path)
+		return common.FileHistoryLoadedMsg{Path: path, Commits: commits, Err: err}
+	}
+}
+
+// PrepareEditorCmd builds the command opening path at line in the user's
+// editor from the top of the work tree.
+func PrepareEditorCmd(svc g.Service, path string, line int) tea.Cmd {
+	return func() tea.Msg {
+		root, err := svc.WorkTreeRoot()
+		if err != nil {
+			return common.EditorReadyMsg{Err: err}
+		}
+		editor, err := svc.Editor()
+		if err != nil {
+			return common.EditorReadyMsg{Err: err}
+		}
+		return common.EditorReadyMsg{Cmd: common.EditorCommand(editor, root, path, line)}
+	}
+}
+
+// PrepareCommitMessageEditorCmd writes message to a COMMIT_EDITMSG file and
+// builds the command editing it in the user's editor.
+func PrepareCommitMessageEditorCmd(svc g.Service, message string) tea.Cmd {
+	return func() tea.Msg {
+		root, err := svc.WorkTreeRoot()
+		if err != nil {
+			return common.EditorReadyMsg{Err: err}
+		}
+		editor, err := svc.Editor()
+		if err != nil {
+			return common.EditorReadyMsg{Err: err}
+		}
+		file, err := svc.WriteCommitMessageFile(message)
+		if err != nil {
+			return common.EditorReadyMsg{Err: err}
+		}
+		return common.EditorReadyMsg{Cmd: common.EditorCommand(editor, root, file, 0), MessageFile: file}
+	}
+}
+
+// ReadCommitMessageCmd reads the edited commit message back and removes its
+// file. The message is dropped when the editor failed, as git does.
+func ReadCommitMessageCmd(svc g.Service, file string, editorErr error) tea.Cmd {
+	return func() tea.Msg {
+		message, err := svc.ReadCommitMessageFile(file)
+		if editorErr != nil {
+			return common.CommitMessageEditedMsg{Err: fmt.Errorf("editor: %w", editorErr)}
+		}
+		return common.CommitMessageEditedMsg{Message: message, Err: err}
+	}
+}
+
+func LoadSnapshotsCmd(svc g.Service) tea.Cmd {
+	return func() tea.Msg {
+		snaps, err := svc.LoadSnapshots()
+		return common.SnapshotsLoadedMsg{Snapshots: snaps, Err: err}
 	}
 }
 
@@ -93,13 +242,13 @@ func WaitWatchCmd(w *g.FSWatcher) tea.Cmd {
 	}
 }
 
-func ExecOpCmd(svc g.Service, op app.Operation, refreshChanges, refreshGraph bool) tea.Cmd {
+func ExecOpCmd(svc g.Service, op app.Operation, refreshChanges, refreshGraph, refreshRepoSummary bool) tea.Cmd {
 	return func() tea.Msg {
 		cmd, err := ExecOperation(svc, op)
 		if err != nil {
 			return common.OpDoneMsg{Err: err, Command: cmd}
 		}
-		return common.OpDoneMsg{RefreshChanges: refreshChanges, RefreshGraph: refreshGraph, Command: cmd}
+		return common.OpDoneMsg{RefreshChanges: refreshChanges, RefreshGraph: refreshGraph, RefreshRepoSummary: refreshRepoSummary, Command: cmd}
 	}
 }
 
@@ -133,11 +282,24 @@ func CreateBranchCmd(svc g.Service, name, source string, pushRemote bool) tea.Cm
 func SwitchBranchCmd(svc g.Service, name string) tea.Cmd {
 	return func() tea.Msg {
 		cmd, err := svc.SwitchBranch(name)
+		if g.IsLocalChangesError(err) {
+			return common.SwitchBlockedMsg{Branch: name, Command: cmd, Err: err}
+		}
 		if err != nil {
 			return common.OpDoneMsg{Err: err, Command: cmd}
 		}
+		commandLog := cmd
+		popCmd, popErr := svc.PopAutostash(name)
+		if commandLog != "" && popCmd != "" {
+			commandLog += " && " + popCmd
+		} else if popCmd != "" {
+			commandLog = popCmd
+		}
+		if popErr != nil {
+			return common.OpDoneMsg{Err: popErr, Command: commandLog}
+		}
 		return common.OpDoneMsg{
-			Command:            cmd,
+			Command:            commandLog,
 			RefreshChanges:     true,
 			RefreshGraph:       true,
 			RefreshRepoSummary: true,
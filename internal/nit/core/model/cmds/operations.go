@@ -18,21 +18,104 @@ func ExecOperation(svc g.Service, op app.Operation) (string, error) {
 	case app.OpDiscardAll:
 		return svc.DiscardAll()
 	case app.OpCommit:
+		// Signing flags are passed only when the toggle was changed from
+		// commit.gpgsign; otherwise git follows its own config.
 		return svc.CommitWithOptions(op.Message, g.CommitOptions{
-			All:     op.CommitAll,
-			Amend:   op.CommitAmend,
-			Signoff: op.CommitSignoff,
+			All:      op.CommitAll,
+			Amend:    op.CommitAmend,
+			Signoff:  op.CommitSignoff,
+			Sign:     op.CommitSign && !op.CommitSignDefault,
+			NoSign:   !op.CommitSign && op.CommitSignDefault,
+			NoVerify: op.NoVerify,
 		})
 	case app.OpPull:
-		return svc.Pull()
+		return svc.Pull(op.NoVerify)
 	case app.OpFetch:
 		return svc.Fetch()
 	case app.OpPush:
-		return svc.Push()
+		return svc.Push(op.NoVerify)
 	case app.OpUndoLastCommit:
 		return svc.UndoLastCommit()
 	case app.OpAbortRebase:
 		return svc.AbortRebase()
+	case app.OpStagePatch:
+		return svc.StagePatch(op.Patch)
+	case app.OpUnstagePatch:
+		return svc.UnstagePatch(op.Patch)
+	case app.OpRestoreSnapshot:
+		return svc.RestoreSnapshot(op.Ref)
+	case app.OpDiscardFile:
+		return svc.DiscardFile(op.Path, op.Target)
+	case app.OpIgnorePath:
+		return svc.IgnorePath(op.Path)
+	case app.OpDiscardPatch:
+		return svc.DiscardPatch(op.Path, op.Patch)
+	case app.OpStashPush:
+		return svc.StashPush(op.Message, op.IncludeUntracked)
+	case app.OpStashApply:
+		return svc.StashApply(op.Ref)
+	case app.OpStashPop:
+		return svc.StashPop(op.Ref)
+	case app.OpStashDrop:
+		return svc.StashDrop(op.Ref)
+	case app.OpStashAndSwitch:
+		return svc.StashAndSwitch(op.Ref)
+	case app.OpSwitchBranchCarry:
+		return svc.SwitchBranchCarry(op.Ref)
+	case app.OpBranchDelete:
+		return svc.DeleteBranch(op.Ref, op.Force)
+	case app.OpBranchRename:
+		return svc.RenameBranch(op.Ref, op.Target)
+	case app.OpBranchSetUpstream:
+		return svc.SetUpstream(op.Ref, op.Target)
+	case app.OpBranchUnsetUpstream:
+		return svc.UnsetUpstream(op.Ref)
+	case app.OpBranchDeleteRemote:
+		return svc.DeleteRemoteBranch(op.Ref)
+	case app.OpBranchTrack:
+		return svc.TrackRemoteBranch(op.Ref, op.Target)
+	case app.OpTagCreate:
+		return svc.CreateTag(op.Target, op.Ref, op.Message)
+	case app.OpTagDelete:
+		return svc.DeleteTag(op.Ref)
+	case app.OpTagPush:
+		return svc.PushTag(op.Ref)
+	case app.OpRebaseInteractive:
+		return svc.StartInteractiveRebase(op.Ref, op.Todo)
+	case app.OpRebaseContinue:
+		return svc.ContinueRebase()
+	case app.OpRebaseSkip:
+		return svc.SkipRebase()
+	case app.OpMerge:
+		return svc.Merge(op.Ref, g.MergeMode(op.MergeMode), op.NoVerify)
+	case app.OpMergeContinue:
+		return svc.ContinueMerge()
+	case app.OpMergeAbort:
+		return svc.AbortMerge()
+	case app.OpCherryPickContinue:
+		return svc.ContinueCherryPick()
+	case app.OpCherryPickSkip:
+		return svc.SkipCherryPick()
+	case app.OpCherryPickAbort:
+		return svc.AbortCherryPick()
+	case app.OpRevertContinue:
+		return svc.ContinueRevert()
+	case app.OpRevertSkip:
+		return svc.SkipRevert()
+	case app.OpRevertAbort:
+		return svc.AbortRevert()
+	case app.OpBisectSkip:
+		return svc.SkipBisect()
+	case app.OpBisectReset:
+		return svc.ResetBisect()
+	case app.OpCherryPick:
+		return svc.CherryPick(op.Hashes, op.RecordOrigin, op.Mainline)
+	case app.OpRevert:
+		return svc.Revert(op.Ref, op.Mainline)
+	case app.OpReset:
+		return svc.Reset(op.Ref, g.ResetMode(op.ResetMode))
+	case app.OpResolveConflict:
+		return svc.ResolveConflict(op.Path, op.Content)
 	default:
 		return "", nil
 	}
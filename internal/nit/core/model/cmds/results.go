@@ -14,7 +14,11 @@ func HandleResult(git g.Service, result app.ApplyResult) tea.Cmd {
 	cmds := make([]tea.Cmd, 0, len(result.Operations)+2)
 	if len(result.Operations) > 0 {
 		for _, op := range result.Operations {
-			cmds = append(cmds, ExecOpCmd(git, op, result.RefreshChanges, result.RefreshGraph))
+			if streamedOp(op) {
+				cmds = append(cmds, StreamOpCmd(git, op, result.RefreshChanges, result.RefreshGraph, result.RefreshRepoSummary))
+				continue
+			}
+			cmds = append(cmds, ExecOpCmd(git, op, result.RefreshChanges, result.RefreshGraph, result.RefreshRepoSummary))
 		}
 	} else {
 		if result.RefreshChanges {
@@ -23,6 +27,10 @@ func HandleResult(git g.Service, result app.ApplyResult) tea.Cmd {
 		if result.RefreshGraph {
 			cmds = append(cmds, LoadGraphCmd(git))
 			cmds = append(cmds, LoadBranchesCmd(git))
+			cmds = append(cmds, LoadStashesCmd(git))
+		}
+		if result.RefreshRepoSummary {
+			cmds = append(cmds, LoadRepoSummaryCmd(git))
 		}
 	}
 	if len(cmds) == 0 {
@@ -0,0 +1,114 @@
+package cmds
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/zGIKS/nit/internal/nit/app"
+	"github.com/zGIKS/nit/internal/nit/core/model/common"
+	g "github.com/zGIKS/nit/internal/nit/git"
+)
+
+var (
+	lastStreamID atomic.Int64
+	// cancelStream holds the cancel function of every running stream by ID.
+	cancelStream sync.Map
+)
+
+// streamedOp reports whether an operation runs hooks or talks to a remote.
+// Those are streamed instead of run through ExecOpCmd.
+func streamedOp(op app.Operation) bool {
+	switch op.Kind {
+	case app.OpCommit, app.OpPush, app.OpPull, app.OpFetch, app.OpMerge, app.OpMergeContinue,
+		app.OpCherryPick, app.OpCherryPickContinue, app.OpCherryPickSkip,
+		app.OpRevert, app.OpRevertContinue, app.OpRevertSkip,
+		app.OpRebaseInteractive, app.OpRebaseContinue, app.OpRebaseSkip:
+		return true
+	}
+	return false
+}
+
+// opHooks lists the hooks an operation can run.
+func opHooks(op app.Operation) []string {
+	switch op.Kind {
+	case app.OpCommit, app.OpMergeContinue, app.OpCherryPickContinue, app.OpRevertContinue:
+		return g.CommitHooks
+	case app.OpCherryPick, app.OpCherryPickSkip, app.OpRevert, app.OpRevertSkip:
+		return g.SequenceHooks
+	case app.OpRebaseInteractive, app.OpRebaseContinue, app.OpRebaseSkip:
+		return g.RebaseHooks
+	case app.OpPush:
+		return g.PushHooks
+	case app.OpPull, app.OpMerge:
+		return g.MergeHooks
+	}
+	return nil
+}
+
+// StreamOpCmd runs a long operation in the background. Its output and
+// progress arrive as OpOutputMsg through WaitOpStreamCmd, followed by an
+// OpDoneMsg. CancelOpCmd stops it.
+func StreamOpCmd(svc g.Service, op app.Operation, refreshChanges, refreshGraph, refreshRepoSummary bool) tea.Cmd {
+	return func() tea.Msg {
+		stream := &common.OpStream{
+			ID:     int(lastStreamID.Add(1)),
+			Output: make(chan common.OpOutputMsg, 64),
+			Done:   make(chan common.OpDoneMsg, 1),
+		}
+		hooks := svc.InstalledHooks(opHooks(op)...)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancelStream.Store(stream.ID, cancel)
+		go func() {
+			defer cancel()
+			cmd, err := ExecOperation(svc.WithStream(g.Stream{
+				Context: ctx,
+				Line: func(line string) {
+					stream.Output <- common.OpOutputMsg{Line: line}
+				},
+				Progress: func(p g.Progress) {
+					// Progress is redrawn often; drop reports the UI cannot
+					// keep up with.
+					select {
+					case stream.Output <- common.OpOutputMsg{Progress: &p}:
+					default:
+					}
+				},
+			}), op)
+			cancelStream.Delete(stream.ID)
+			close(stream.Output)
+			done := common.OpDoneMsg{Err: err, Command: cmd, StreamID: stream.ID}
+			if err == nil {
+				done.RefreshChanges = refreshChanges
+				done.RefreshGraph = refreshGraph
+				done.RefreshRepoSummary = refreshRepoSummary
+			}
+			stream.Done <- done
+		}()
+		return common.OpStartedMsg{Stream: stream, Op: op, Hooks: hooks}
+	}
+}
+
+// WaitOpStreamCmd waits for the next line or progress report of a streamed
+// operation, or for its result once the output is over.
+func WaitOpStreamCmd(stream *common.OpStream) tea.Cmd {
+	return func() tea.Msg {
+		if msg, ok := <-stream.Output; ok {
+			msg.Stream = stream
+			return msg
+		}
+		return <-stream.Done
+	}
+}
+
+// CancelOpCmd cancels a streamed operation. Its OpDoneMsg still arrives, with
+// an error wrapping git.ErrCancelled.
+func CancelOpCmd(id int) tea.Cmd {
+	return func() tea.Msg {
+		if cancel, ok := cancelStream.Load(id); ok {
+			cancel.(context.CancelFunc)()
+		}
+		return nil
+	}
+}
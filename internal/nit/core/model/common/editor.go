@@ -0,0 +1,67 @@
+package common
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// EditorCommand opens path, relative to dir unless absolute, in editor, a
+// shell command such as "code --wait" as git reports it. When line is above
+// 0 the editor is asked to start there, in the way the editor understands.
+func EditorCommand(editor, dir, path string, line int) *exec.Cmd {
+	args := append([]string{"-c", editor + ` "$@"`, editor}, editorArgs(editor, filepath.FromSlash(path), line)...)
+	cmd := exec.Command("sh", args...)
+	cmd.Dir = dir
+	return cmd
+}
+
+// editorArgs are the arguments opening path at line. Editors nit does not
+// know only get the path, since they might take "+line" for a file name.
+func editorArgs(editor, path string, line int) []string {
+	if line <= 0 {
+		return []string{path}
+	}
+	name := ""
+	if fields := strings.Fields(editor); len(fields) > 0 {
+		name = strings.TrimSuffix(filepath.Base(fields[0]), ".exe")
+	}
+	switch name {
+	case "code", "code-insiders", "codium", "cursor", "windsurf":
+		return []string{"--goto", fmt.Sprintf("%s:%d", path, line)}
+	case "subl", "zed", "hx", "helix":
+		return []string{fmt.Sprintf("%s:%d", path, line)}
+	case "vi", "vim", "nvim", "gvim", "mvim", "view", "nano", "pico", "micro",
+		"emacs", "emacsclient", "kak", "joe", "mg", "vis", "ne", "gedit":
+		return []string{fmt.Sprintf("+%d", line), path}
+	}
+	return []string{path}
+}
+
+// MouseMode is the mouse tracking asked for through NIT_MOUSE_MODE: "cell",
+// the default, "all" or "off".
+func MouseMode() string {
+	switch mode := strings.ToLower(strings.TrimSpace(os.Getenv("NIT_MOUSE_MODE"))); mode {
+	case "all", "off":
+		return mode
+	default:
+		return "cell"
+	}
+}
+
+// EnableMouseCmd turns mouse tracking back on after a program that had the
+// terminal, such as the editor, exits; restoring the terminal does not.
+func EnableMouseCmd() tea.Cmd {
+	switch MouseMode() {
+	case "all":
+		return tea.EnableMouseAllMotion
+	case "off":
+		return nil
+	default:
+		return tea.EnableMouseCellMotion
+	}
+}
@@ -1,6 +1,11 @@
 package common
 
-import g "github.com/zGIKS/nit/internal/nit/git"
+import (
+	"os/exec"
+
+	"github.com/zGIKS/nit/internal/nit/app"
+	g "github.com/zGIKS/nit/internal/nit/git"
+)
 
 type PollMsg struct{}
 type GraphPollMsg struct{}
@@ -17,25 +22,146 @@ type ChangesLoadedMsg struct {
 }
 
 type GraphLoadedMsg struct {
-	Lines []string
-	Err   error
+	Commits []g.Commit
+	Err     error
+}
+
+type CommitDetailLoadedMsg struct {
+	Detail g.CommitDetail
+	Err    error
+}
+
+type CommitFileDiffLoadedMsg struct {
+	Hash string
+	Diff g.FileDiff
+	Err  error
+}
+
+type DiffLoadedMsg struct {
+	Diff g.FileDiff
+	Err  error
 }
 
 type BranchesLoadedMsg struct {
-	Lines []string
-	Err   error
+	Branches []g.Branch
+	Err      error
+}
+
+type StashesLoadedMsg struct {
+	Stashes []g.Stash
+	Err     error
 }
 
 type RepoSummaryLoadedMsg struct {
-	Repo   string
-	Branch string
+	Summary g.RepoSummary
+	Err     error
+}
+
+type CommitTemplateLoadedMsg struct {
+	Template string
+	Err      error
+}
+
+type SigningConfigLoadedMsg struct {
+	Config g.SigningConfig
 	Err    error
 }
 
+type AmendCommitLoadedMsg struct {
+	Commit   g.CommitDetail
+	PushedTo string
+	Err      error
+}
+
+type RebaseTodoLoadedMsg struct {
+	Base  string
+	Items []g.RebaseTodoItem
+	Err   error
+}
+
+type ConflictLoadedMsg struct {
+	File g.ConflictFile
+	Err  error
+}
+
+type ResetPreviewLoadedMsg struct {
+	Hash    string
+	Preview g.ResetPreview
+	Err     error
+}
+
+type FileHistoryLoadedMsg struct {
+	Path    string
+	Commits []g.Commit
+	Err     error
+}
+
+// EditorReadyMsg carries the editor command to hand the terminal to.
+// MessageFile is set when the editor edits the commit message.
+type EditorReadyMsg struct {
+	Cmd         *exec.Cmd
+	MessageFile string
+	Err         error
+}
+
+type EditorClosedMsg struct {
+	MessageFile string
+	Err         error
+}
+
+type CommitMessageEditedMsg struct {
+	Message string
+	Err     error
+}
+
+type SnapshotsLoadedMsg struct {
+	Snapshots []g.Snapshot
+	Err       error
+}
+
+type UnmergedCommitsLoadedMsg struct {
+	Branch  string
+	Commits []g.Commit
+	Err     error
+}
+
+// SwitchBlockedMsg reports a branch switch refused because local changes
+// would be overwritten.
+type SwitchBlockedMsg struct {
+	Branch  string
+	Command string
+	Err     error
+}
+
 type OpDoneMsg struct {
 	Err                error
 	RefreshChanges     bool
 	RefreshGraph       bool
 	RefreshRepoSummary bool
 	Command            string
+	StreamID           int
+}
+
+// OpStream carries the output and progress of a long-running operation, and
+// then its result. Output is closed before Done is sent.
+type OpStream struct {
+	ID     int
+	Output chan OpOutputMsg
+	Done   chan OpDoneMsg
+}
+
+// OpStartedMsg reports a streamed operation that started, with the hooks
+// that are installed for it.
+type OpStartedMsg struct {
+	Stream *OpStream
+	Op     app.Operation
+	Hooks  []string
+}
+
+// OpOutputMsg is a line a streamed operation printed, or a progress report
+// when Progress is set.
+type OpOutputMsg struct {
+	Stream   *OpStream
+	Line     string
+	Progress *g.Progress
 }
@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/zGIKS/nit/internal/nit/app"
+	"github.com/zGIKS/nit/internal/nit/core/model/cmds"
+	"github.com/zGIKS/nit/internal/nit/core/model/common"
+	g "github.com/zGIKS/nit/internal/nit/git"
+)
+
+func openSelectedCommitDetail(state *app.AppState, git g.Service) tea.Cmd {
+	commit, ok := state.SelectedCommit()
+	if !ok {
+		state.Clamp()
+		return nil
+	}
+	state.OpenCommitDetail(commit.Hash, "Commit "+commit.ShortHash)
+	state.BeginCommitDetailLoad()
+	state.Clamp()
+	return cmds.LoadCommitDetailCmd(git, commit.Hash)
+}
+
+// openSelectedStashDetail previews a stash in the commit detail view. A stash
+// is a commit whose first parent is the commit it was made on, so its diff
+// against that parent is the stashed change.
+func openSelectedStashDetail(state *app.AppState, git g.Service) tea.Cmd {
+	st, ok := state.SelectedStash()
+	if !ok {
+		state.Clamp()
+		return nil
+	}
+	state.OpenCommitDetail(st.Hash, st.Ref)
+	state.BeginCommitDetailLoad()
+	state.Clamp()
+	return cmds.LoadCommitDetailCmd(git, st.Hash)
+}
+
+func handleCommitDetailKey(state *app.AppState, git g.Service, msg tea.KeyMsg) tea.Cmd {
+	if msg.Type == tea.KeyEsc {
+		state.CloseCommitDetail()
+		state.Clamp()
+		return nil
+	}
+	switch action := state.Keys.Match(msg.String()); action {
+	case app.ActionQuit:
+		if msg.Type == tea.KeyCtrlC {
+			return cmds.HandleResult(git, state.Apply(action))
+		}
+		state.CloseCommitDetail()
+	case app.ActionMoveUp:
+		state.MoveCommitDetailCursor(-1)
+	case app.ActionMoveDown:
+		state.MoveCommitDetailCursor(1)
+	case app.ActionTogglePanel:
+		state.ToggleCommitDetailFocus()
+	case app.ActionToggleOne:
+		if !state.CommitDetail.DiffFocus {
+			state.ToggleCommitDetailFocus()
+		}
+	}
+	state.Clamp()
+	return nil
+}
+
+// SyncCommitDetail loads the details of a commit opened from somewhere other
+// than the graph or the stash, such as the history of a file.
+func SyncCommitDetail(state *app.AppState, git g.Service) tea.Cmd {
+	hash, ok := state.CommitDetailTarget()
+	if !ok {
+		return nil
+	}
+	state.BeginCommitDetailLoad()
+	return cmds.LoadCommitDetailCmd(git, hash)
+}
+
+func HandleCommitDetailLoaded(state *app.AppState, msg common.CommitDetailLoadedMsg) tea.Cmd {
+	if !state.CommitDetail.Open || state.CommitDetail.Hash != msg.Detail.Hash {
+		return nil
+	}
+	if msg.Err != nil {
+		state.CloseCommitDetail()
+		state.SetError(msg.Err.Error())
+		state.Clamp()
+		return nil
+	}
+	state.SetCommitDetail(msg.Detail)
+	state.Clamp()
+	return nil
+}
+
+func HandleCommitFileDiffLoaded(state *app.AppState, msg common.CommitFileDiffLoadedMsg) tea.Cmd {
+	if msg.Err != nil {
+		state.SetError(msg.Err.Error())
+		state.Clamp()
+		return nil
+	}
+	state.SetCommitFileDiff(msg.Hash, msg.Diff)
+	state.Clamp()
+	return nil
+}
+
+// SyncCommitFileDiff loads the diff for the file selected in the commit
+// detail view once the selection changes.
+func SyncCommitFileDiff(state *app.AppState, git g.Service) tea.Cmd {
+	file, ok := state.CommitDetailFileTarget()
+	if !ok || state.CommitDetail.Diff.Path == file.Path {
+		return nil
+	}
+	state.BeginCommitFileDiffLoad(file.Path)
+	return cmds.LoadCommitFileDiffCmd(git, state.CommitDetail.Detail, file)
+}
@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/zGIKS/nit/internal/nit/app"
+	"github.com/zGIKS/nit/internal/nit/config"
+	"github.com/zGIKS/nit/internal/nit/core/model/cmds"
+	"github.com/zGIKS/nit/internal/nit/core/model/common"
+	g "github.com/zGIKS/nit/internal/nit/git"
+)
+
+// handleConfirmKey drives the confirmation modal: a choice between the
+// operation and Cancel, or a "yes" to type for the hardest operations.
+func handleConfirmKey(
+	state *app.AppState,
+	git g.Service,
+	clipCfg config.ClipboardConfig,
+	textKeys config.CommitEditorKeyConfig,
+	pasteHintAlreadySeen *bool,
+	msg tea.KeyMsg,
+) tea.Cmd {
+	if msg.Type == tea.KeyEsc {
+		state.CloseConfirm()
+		state.Clamp()
+		return nil
+	}
+	if msg.Type == tea.KeyCtrlC {
+		return cmds.HandleResult(git, state.Apply(app.ActionQuit))
+	}
+	if state.Confirm.TypeYes {
+		switch {
+		case matchesConfiguredKey(msg, textKeys.Submit):
+			result := state.AcceptConfirm()
+			state.Clamp()
+			return cmds.HandleResult(git, result)
+		case matchesConfiguredKey(msg, textKeys.Cancel):
+			state.CloseConfirm()
+		default:
+			handleSharedTextInputKey(state, clipCfg, textKeys, pasteHintAlreadySeen, msg, textInputKeyOps{
+				Selected:        state.SelectedConfirmText,
+				Append:          state.ConfirmAppendText,
+				Backspace:       state.ConfirmBackspace,
+				Delete:          state.ConfirmDelete,
+				MoveLeft:        state.ConfirmCursorLeft,
+				MoveRight:       state.ConfirmCursorRight,
+				MoveHome:        state.ConfirmCursorHome,
+				MoveEnd:         state.ConfirmCursorEnd,
+				SelectAll:       state.ConfirmSelectAllText,
+				DeleteSelection: state.DeleteConfirmSelection,
+			})
+		}
+		state.Clamp()
+		return nil
+	}
+	switch state.Keys.Match(msg.String()) {
+	case app.ActionQuit:
+		state.CloseConfirm()
+	case app.ActionMoveUp:
+		state.MoveConfirmChoice(-1)
+	case app.ActionMoveDown:
+		state.MoveConfirmChoice(1)
+	case app.ActionToggleOne:
+		result := state.AcceptConfirm()
+		state.Clamp()
+		return cmds.HandleResult(git, result)
+	}
+	state.Clamp()
+	return nil
+}
+
+func SyncConfirmUnmerged(state *app.AppState, git g.Service) tea.Cmd {
+	branch, ok := state.ConfirmUnmergedTarget()
+	if !ok {
+		return nil
+	}
+	state.BeginConfirmLoad()
+	return cmds.LoadUnmergedCommitsCmd(git, branch)
+}
+
+func HandleUnmergedCommitsLoaded(state *app.AppState, msg common.UnmergedCommitsLoadedMsg) tea.Cmd {
+	if !state.Confirm.Open || state.Confirm.Branch != msg.Branch {
+		return nil
+	}
+	if msg.Err != nil {
+		state.CloseConfirm()
+		state.SetError(msg.Err.Error())
+		state.Clamp()
+		return nil
+	}
+	state.SetConfirmUnmerged(msg.Branch, msg.Commits)
+	state.Clamp()
+	return nil
+}
@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/zGIKS/nit/internal/nit/app"
+	"github.com/zGIKS/nit/internal/nit/core/model/cmds"
+	"github.com/zGIKS/nit/internal/nit/core/model/common"
+	g "github.com/zGIKS/nit/internal/nit/git"
+)
+
+// conflictChoiceKeys pick the side kept for the conflict block under the
+// cursor. They are only read while the conflict view is open.
+var conflictChoiceKeys = map[string]g.ConflictChoice{
+	"o": g.ConflictOurs,
+	"t": g.ConflictTheirs,
+	"b": g.ConflictBoth,
+}
+
+func handleConflictKey(state *app.AppState, git g.Service, msg tea.KeyMsg) tea.Cmd {
+	if msg.Type == tea.KeyEsc {
+		state.CloseConflictView()
+		state.Clamp()
+		return nil
+	}
+	if choice, ok := conflictChoiceKeys[msg.String()]; ok {
+		state.ChooseConflictSide(choice)
+		state.Clamp()
+		return nil
+	}
+	switch msg.String() {
+	case "pgup":
+		state.ScrollConflict(-10)
+		state.Clamp()
+		return nil
+	case "pgdown":
+		state.ScrollConflict(10)
+		state.Clamp()
+		return nil
+	}
+	switch action := state.Keys.Match(msg.String()); action {
+	case app.ActionQuit:
+		if msg.Type == tea.KeyCtrlC {
+			return cmds.HandleResult(git, state.Apply(action))
+		}
+		state.CloseConflictView()
+	case app.ActionMoveUp:
+		state.MoveConflictHunk(-1)
+	case app.ActionMoveDown:
+		state.MoveConflictHunk(1)
+	case app.ActionToggleOne:
+		result := state.ResolveConflict()
+		state.Clamp()
+		return cmds.HandleResult(git, result)
+	}
+	state.Clamp()
+	return nil
+}
+
+func SyncConflict(state *app.AppState, git g.Service) tea.Cmd {
+	path, ok := state.ConflictTarget()
+	if !ok {
+		return nil
+	}
+	state.BeginConflictLoad()
+	return cmds.LoadConflictCmd(git, path)
+}
+
+func HandleConflictLoaded(state *app.AppState, msg common.ConflictLoadedMsg) tea.Cmd {
+	if !state.Conflict.Open || state.Conflict.Path != msg.File.Path {
+		return nil
+	}
+	if msg.Err != nil {
+		state.CloseConflictView()
+		state.SetError(msg.Err.Error())
+		state.Clamp()
+		return nil
+	}
+	state.SetConflict(msg.File)
+	state.Clamp()
+	return nil
+}
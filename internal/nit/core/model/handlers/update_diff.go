@@ -0,0 +1,42 @@
+package handlers
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/zGIKS/nit/internal/nit/app"
+	"github.com/zGIKS/nit/internal/nit/core/model/cmds"
+	"github.com/zGIKS/nit/internal/nit/core/model/common"
+	g "github.com/zGIKS/nit/internal/nit/git"
+)
+
+func HandleDiffLoaded(state *app.AppState, msg common.DiffLoadedMsg) tea.Cmd {
+	if !state.DiffIsFor(msg.Diff.Path, msg.Diff.Staged) {
+		// The cursor moved on while git was running; a newer load is pending.
+		return nil
+	}
+	if msg.Err != nil {
+		state.SetError(msg.Err.Error())
+		state.Clamp()
+		return nil
+	}
+	state.SetDiff(msg.Diff)
+	state.Clamp()
+	return nil
+}
+
+// SyncDiff keeps the diff pane pointed at the Changes selection. force reloads
+// the current target even when it did not change, e.g. after the file changed
+// on disk.
+func SyncDiff(state *app.AppState, git g.Service, force bool) tea.Cmd {
+	path, staged, ok := state.DiffTarget()
+	if !ok {
+		if state.Diff.Path != "" {
+			state.ClearDiff()
+		}
+		return nil
+	}
+	if !force && state.DiffIsFor(path, staged) {
+		return nil
+	}
+	state.BeginDiffLoad(path, staged)
+	return cmds.LoadDiffCmd(git, path, staged)
+}
@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/zGIKS/nit/internal/nit/app"
+	"github.com/zGIKS/nit/internal/nit/config"
+	"github.com/zGIKS/nit/internal/nit/core/model/cmds"
+	"github.com/zGIKS/nit/internal/nit/core/model/common"
+	g "github.com/zGIKS/nit/internal/nit/git"
+)
+
+func SyncFileHistory(state *app.AppState, git g.Service) tea.Cmd {
+	path, ok := state.FileHistoryTarget()
+	if !ok {
+		return nil
+	}
+	state.BeginFileHistoryLoad()
+	return cmds.LoadFileHistoryCmd(git, path)
+}
+
+func HandleFileHistoryLoaded(state *app.AppState, msg common.FileHistoryLoadedMsg) tea.Cmd {
+	if msg.Err != nil {
+		state.FileHistoryFailed(msg.Err)
+		state.Clamp()
+		return nil
+	}
+	state.SetFileHistory(msg.Path, msg.Commits)
+	state.Clamp()
+	return nil
+}
+
+// SyncCopyPath copies the path asked for to the clipboard.
+func SyncCopyPath(state *app.AppState, clipCfg config.ClipboardConfig) tea.Cmd {
+	text, ok := state.TakeCopyRequest()
+	if !ok {
+		return nil
+	}
+	if err := common.CopyWithMode(clipCfg, text); err != nil {
+		state.SetError(err.Error())
+	}
+	return nil
+}
+
+func SyncEditor(state *app.AppState, git g.Service) tea.Cmd {
+	req, ok := state.TakeEditorRequest()
+	if !ok {
+		return nil
+	}
+	if req.CommitMessage {
+		return cmds.PrepareCommitMessageEditorCmd(git, req.Message)
+	}
+	return cmds.PrepareEditorCmd(git, req.Path, req.Line)
+}
+
+// HandleEditorReady hands the terminal to the editor until it exits.
+func HandleEditorReady(state *app.AppState, msg common.EditorReadyMsg) tea.Cmd {
+	if msg.Err != nil {
+		state.SetError(msg.Err.Error())
+		state.Clamp()
+		return nil
+	}
+	file := msg.MessageFile
+	return tea.ExecProcess(msg.Cmd, func(err error) tea.Msg {
+		return common.EditorClosedMsg{MessageFile: file, Err: err}
+	})
+}
+
+// HandleEditorClosed turns the mouse back on and reads back the commit
+// message, or reloads the changes, which the editor may have touched.
+func HandleEditorClosed(state *app.AppState, git g.Service, msg common.EditorClosedMsg) tea.Cmd {
+	if msg.MessageFile != "" {
+		return tea.Batch(common.EnableMouseCmd(), cmds.ReadCommitMessageCmd(git, msg.MessageFile, msg.Err))
+	}
+	if msg.Err != nil {
+		state.SetError("editor: " + msg.Err.Error())
+		state.Clamp()
+	}
+	return tea.Batch(common.EnableMouseCmd(), cmds.LoadChangesCmd(git))
+}
+
+// HandleCommitMessageEdited puts the message written in the editor into the
+// commit input; an empty one leaves the input as it was.
+func HandleCommitMessageEdited(state *app.AppState, msg common.CommitMessageEditedMsg) tea.Cmd {
+	if msg.Err != nil {
+		state.SetError(msg.Err.Error())
+	} else {
+		state.SetCommitMessageFromEditor(msg.Message)
+	}
+	state.Clamp()
+	return nil
+}
@@ -1,6 +1,8 @@
 package handlers
 
 import (
+	"errors"
+
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/zGIKS/nit/internal/nit/app"
 	"github.com/zGIKS/nit/internal/nit/core/model/cmds"
@@ -8,20 +10,73 @@ import (
 	g "github.com/zGIKS/nit/internal/nit/git"
 )
 
-func HandleChangesLoaded(state *app.AppState, msg common.ChangesLoadedMsg) tea.Cmd {
-	return handleLoadResult(state, msg.Err, func() {
+func HandleChangesLoaded(state *app.AppState, git g.Service, msg common.ChangesLoadedMsg) tea.Cmd {
+	handleLoadResult(state, msg.Err, func() {
 		if !common.SameChanges(state.Changes.Entries, msg.Entries) {
 			state.SetChanges(msg.Entries)
 		}
 	})
+	if msg.Err != nil {
+		return nil
+	}
+	// Porcelain output does not change when an already modified file is
+	// edited again, so the diff is refreshed on every load.
+	return SyncDiff(state, git, true)
 }
 
 func HandleGraphLoaded(state *app.AppState, msg common.GraphLoadedMsg) tea.Cmd {
-	return handleLoadResult(state, msg.Err, func() { state.SetGraph(msg.Lines) })
+	return handleLoadResult(state, msg.Err, func() { state.SetGraph(msg.Commits) })
 }
 
 func HandleBranchesLoaded(state *app.AppState, msg common.BranchesLoadedMsg) tea.Cmd {
-	return handleLoadResult(state, msg.Err, func() { state.SetBranches(msg.Lines) })
+	return handleLoadResult(state, msg.Err, func() { state.SetBranches(msg.Branches) })
+}
+
+func HandleStashesLoaded(state *app.AppState, msg common.StashesLoadedMsg) tea.Cmd {
+	return handleLoadResult(state, msg.Err, func() { state.SetStashes(msg.Stashes) })
+}
+
+func HandleCommitTemplateLoaded(state *app.AppState, msg common.CommitTemplateLoadedMsg) tea.Cmd {
+	if msg.Err != nil {
+		state.SetError(msg.Err.Error())
+		return nil
+	}
+	state.SetCommitTemplate(msg.Template)
+	state.Clamp()
+	return nil
+}
+
+func HandleAmendCommitLoaded(state *app.AppState, msg common.AmendCommitLoadedMsg) tea.Cmd {
+	if !state.Command.AmendLoading {
+		return nil
+	}
+	if msg.Err != nil {
+		state.AmendLoadFailed("cannot amend: " + msg.Err.Error())
+		state.Clamp()
+		return nil
+	}
+	state.SetAmendCommit(msg.Commit.ShortHash, msg.Commit.Message, msg.PushedTo)
+	state.Clamp()
+	return nil
+}
+
+// SyncAmendMessage loads HEAD's message once amend is turned on.
+func SyncAmendMessage(state *app.AppState, git g.Service) tea.Cmd {
+	if !state.AmendMessageNeeded() {
+		return nil
+	}
+	state.BeginAmendLoad()
+	return cmds.LoadAmendCommitCmd(git)
+}
+
+func HandleSigningConfigLoaded(state *app.AppState, msg common.SigningConfigLoadedMsg) tea.Cmd {
+	if msg.Err != nil {
+		state.SetError(msg.Err.Error())
+		return nil
+	}
+	state.SetSigningConfig(msg.Config.Sign, msg.Config.Format)
+	state.Clamp()
+	return nil
 }
 
 func HandleRepoSummaryLoaded(state *app.AppState, msg common.RepoSummaryLoadedMsg) tea.Cmd {
@@ -31,7 +86,9 @@ func HandleRepoSummaryLoaded(state *app.AppState, msg common.RepoSummaryLoadedMs
 		}
 		state.SetError(msg.Err.Error())
 	} else {
-		state.SetRepoSummary(msg.Repo, msg.Branch)
+		state.SetRepoSummary(msg.Summary.Repo, msg.Summary.Branch)
+		state.SetUpstreamStatus(msg.Summary.Upstream, msg.Summary.Ahead, msg.Summary.Behind)
+		state.SetRepoState(msg.Summary.State, msg.Summary.Step, msg.Summary.Steps)
 		if state.LastErr == "" {
 			state.SetError("")
 		}
@@ -57,8 +114,21 @@ func HandleOpDone(state *app.AppState, git g.Service, msg common.OpDoneMsg) tea.
 	if msg.Command != "" {
 		state.AddCommandLog(msg.Command)
 	}
+	if msg.StreamID != 0 {
+		state.FinishOpOutput(msg.StreamID, msg.Err)
+	}
 	if msg.Err != nil {
 		state.SetError(msg.Err.Error())
+		var signErr *g.SigningError
+		if errors.As(msg.Err, &signErr) {
+			state.OpenSigningFailedDialog(signErr.Program, signErr.Output)
+		}
+		var conflictErr *g.MergeConflictError
+		if errors.As(msg.Err, &conflictErr) {
+			state.OpenMergeConflictDialog(conflictErr.Files, conflictErr.State)
+			state.Clamp()
+			return tea.Batch(cmds.LoadChangesCmd(git), cmds.LoadGraphCmd(git), cmds.LoadRepoSummaryCmd(git))
+		}
 		state.Clamp()
 		return nil
 	}
@@ -70,6 +140,7 @@ func HandleOpDone(state *app.AppState, git g.Service, msg common.OpDoneMsg) tea.
 	if msg.RefreshGraph {
 		cmdsToRun = append(cmdsToRun, cmds.LoadGraphCmd(git))
 		cmdsToRun = append(cmdsToRun, cmds.LoadBranchesCmd(git))
+		cmdsToRun = append(cmdsToRun, cmds.LoadStashesCmd(git))
 	}
 	if msg.RefreshRepoSummary {
 		cmdsToRun = append(cmdsToRun, cmds.LoadRepoSummaryCmd(git))
@@ -18,6 +18,44 @@ func HandleKeyMsg(
 	pasteHintAlreadySeen *bool,
 	msg tea.KeyMsg,
 ) tea.Cmd {
+	if state.Confirm.Open {
+		return handleConfirmKey(state, git, clipCfg, textKeys, pasteHintAlreadySeen, msg)
+	}
+
+	if state.Dialog.Open {
+		return handleDialogKey(state, git, msg)
+	}
+
+	if state.Keys.Match(msg.String()) == app.ActionCancelOperation {
+		if id, ok := state.CancelOpOutput(); ok {
+			return cmds.CancelOpCmd(id)
+		}
+	}
+
+	if state.OpOutput.Open {
+		return handleOpOutputKey(state, git, msg)
+	}
+
+	if state.CommitDetail.Open {
+		return handleCommitDetailKey(state, git, msg)
+	}
+
+	if state.Prompt.Open {
+		return handlePromptKey(state, git, clipCfg, textKeys, pasteHintAlreadySeen, msg)
+	}
+
+	if state.Rebase.Open {
+		return handleRebaseKey(state, git, msg)
+	}
+
+	if state.Conflict.Open {
+		return handleConflictKey(state, git, msg)
+	}
+
+	if state.Reset.Open {
+		return handleResetKey(state, git, msg)
+	}
+
 	if state.BranchCreateOpen {
 		return handleBranchCreateKey(state, git, clipCfg, textKeys, pasteHintAlreadySeen, msg)
 	}
@@ -99,6 +137,41 @@ func HandleKeyMsg(
 			state.ExitCommandFocus()
 			state.Clamp()
 			return nil
+		case matchesConfiguredKey(msg, textKeys.Newline):
+			state.InsertCommandNewline()
+			state.Clamp()
+			return nil
+		case matchesConfiguredKey(msg, textKeys.Trailer):
+			state.OpenCommitTrailerPicker()
+			state.Clamp()
+			return nil
+		case matchesConfiguredKey(msg, textKeys.CommitType):
+			state.OpenCommitTypePicker()
+			state.Clamp()
+			return nil
+		case matchesConfiguredKey(msg, textKeys.Amend):
+			state.ToggleCommitAmend()
+			state.Clamp()
+			return nil
+		case matchesConfiguredKey(msg, textKeys.Sign):
+			state.ToggleCommitSign()
+			state.Clamp()
+			return nil
+		case matchesConfiguredKey(msg, textKeys.ExternalEditor):
+			state.RequestCommitMessageEditor()
+			return nil
+		case matchesConfiguredKey(msg, textKeys.WrapBody):
+			state.WrapCommitBody()
+			state.Clamp()
+			return nil
+		case matchesConfiguredKey(msg, textKeys.Up):
+			state.MoveCommandCursorUp()
+			state.Clamp()
+			return nil
+		case matchesConfiguredKey(msg, textKeys.Down):
+			state.MoveCommandCursorDown()
+			state.Clamp()
+			return nil
 		}
 		if handleSharedTextInputKey(state, clipCfg, textKeys, pasteHintAlreadySeen, msg, textInputKeyOps{
 			Selected:        state.SelectedCommandText,
@@ -107,8 +180,8 @@ func HandleKeyMsg(
 			Delete:          state.DeleteCommandText,
 			MoveLeft:        state.MoveCommandCursorLeft,
 			MoveRight:       state.MoveCommandCursorRight,
-			MoveHome:        state.MoveCommandCursorToStart,
-			MoveEnd:         state.MoveCommandCursorToEnd,
+			MoveHome:        state.MoveCommandCursorLineStart,
+			MoveEnd:         state.MoveCommandCursorLineEnd,
 			SelectAll:       state.SelectAllCommandText,
 			DeleteSelection: state.DeleteCommandSelection,
 		}) {
@@ -127,7 +200,7 @@ func HandleKeyMsg(
 		return nil
 	}
 	if state.Focus == app.FocusBranches && msg.Type == tea.KeyEnter {
-		branch, ok := state.SelectedBranchName()
+		branch, ok := state.ActivateBranchRow()
 		if !ok {
 			state.Clamp()
 			return nil
@@ -141,6 +214,12 @@ func HandleKeyMsg(
 	}
 
 	action := state.Keys.Match(msg.String())
+	if state.Focus == app.FocusGraph && action == app.ActionToggleOne {
+		return openSelectedCommitDetail(state, git)
+	}
+	if state.Focus == app.FocusStash && action == app.ActionToggleOne {
+		return openSelectedStashDetail(state, git)
+	}
 	result := state.Apply(action)
 	state.Clamp()
 	return cmds.HandleResult(git, result)
@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/zGIKS/nit/internal/nit/app"
+	"github.com/zGIKS/nit/internal/nit/core/model/cmds"
+	"github.com/zGIKS/nit/internal/nit/core/model/common"
+	g "github.com/zGIKS/nit/internal/nit/git"
+)
+
+func handleDialogKey(state *app.AppState, git g.Service, msg tea.KeyMsg) tea.Cmd {
+	if msg.Type == tea.KeyEsc {
+		state.CloseDialog()
+		state.Clamp()
+		return nil
+	}
+	switch action := state.Keys.Match(msg.String()); action {
+	case app.ActionQuit:
+		if msg.Type == tea.KeyCtrlC {
+			return cmds.HandleResult(git, state.Apply(action))
+		}
+		state.CloseDialog()
+	case app.ActionMoveUp:
+		state.MoveDialogCursor(-1)
+	case app.ActionMoveDown:
+		state.MoveDialogCursor(1)
+	case app.ActionToggleOne:
+		result := state.ChooseDialogOption()
+		state.Clamp()
+		return cmds.HandleResult(git, result)
+	}
+	state.Clamp()
+	return nil
+}
+
+// HandleSwitchBlocked asks the user what to do with the local changes that
+// kept a branch switch from going through.
+func HandleSwitchBlocked(state *app.AppState, msg common.SwitchBlockedMsg) tea.Cmd {
+	if msg.Command != "" {
+		state.AddCommandLog(msg.Command)
+	}
+	state.SetError("")
+	state.OpenSwitchBlockedDialog(msg.Branch, g.LocalChangesFiles(msg.Err))
+	state.Clamp()
+	return nil
+}
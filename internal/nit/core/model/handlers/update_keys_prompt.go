@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/zGIKS/nit/internal/nit/app"
+	"github.com/zGIKS/nit/internal/nit/config"
+	"github.com/zGIKS/nit/internal/nit/core/model/cmds"
+	g "github.com/zGIKS/nit/internal/nit/git"
+)
+
+func handlePromptKey(
+	state *app.AppState,
+	git g.Service,
+	clipCfg config.ClipboardConfig,
+	textKeys config.CommitEditorKeyConfig,
+	pasteHintAlreadySeen *bool,
+	msg tea.KeyMsg,
+) tea.Cmd {
+	switch {
+	case msg.Type == tea.KeyTab:
+		state.TogglePromptOption()
+	case matchesConfiguredKey(msg, textKeys.Cancel):
+		state.ClosePrompt()
+	case state.Prompt.Multiline && matchesConfiguredKey(msg, textKeys.Newline):
+		state.PromptInsertNewline()
+	case state.Prompt.Multiline && matchesConfiguredKey(msg, textKeys.Up):
+		state.PromptCursorUp()
+	case state.Prompt.Multiline && matchesConfiguredKey(msg, textKeys.Down):
+		state.PromptCursorDown()
+	case matchesConfiguredKey(msg, textKeys.Submit):
+		result := state.SubmitPrompt()
+		state.Clamp()
+		return cmds.HandleResult(git, result)
+	default:
+		handleSharedTextInputKey(state, clipCfg, textKeys, pasteHintAlreadySeen, msg, textInputKeyOps{
+			Selected:        state.SelectedPromptText,
+			Append:          state.PromptAppendText,
+			Backspace:       state.PromptBackspace,
+			Delete:          state.PromptDelete,
+			MoveLeft:        state.PromptCursorLeft,
+			MoveRight:       state.PromptCursorRight,
+			MoveHome:        state.PromptCursorHome,
+			MoveEnd:         state.PromptCursorEnd,
+			SelectAll:       state.PromptSelectAllText,
+			DeleteSelection: state.DeletePromptSelection,
+		})
+	}
+	state.Clamp()
+	return nil
+}
@@ -14,6 +14,28 @@ func HandleMouseMsg(state *app.AppState, git g.Service, msg tea.MouseMsg) tea.Cm
 		return nil
 	}
 	if msg.Action == tea.MouseActionPress && msg.Button == tea.MouseButtonLeft {
+		if chosen, consumed := state.ConfirmClick(msg.X, msg.Y); consumed {
+			if chosen {
+				result := state.AcceptConfirm()
+				state.Clamp()
+				return cmds.HandleResult(git, result)
+			}
+			state.Clamp()
+			return nil
+		}
+		if chosen, consumed := state.DialogClick(msg.X, msg.Y); consumed {
+			if chosen {
+				result := state.ChooseDialogOption()
+				state.Clamp()
+				return cmds.HandleResult(git, result)
+			}
+			state.Clamp()
+			return nil
+		}
+		if state.OpOutputClick(msg.X, msg.Y) || state.CommitDetailClick(msg.X, msg.Y) || state.PromptClick(msg.X, msg.Y) || state.RebaseClick(msg.X, msg.Y) || state.ConflictClick(msg.X, msg.Y) || state.ResetClick(msg.X, msg.Y) {
+			state.Clamp()
+			return nil
+		}
 		if state.BranchCreateOpen {
 			if state.BranchCreateClick(msg.X, msg.Y) {
 				state.Clamp()
@@ -61,7 +83,16 @@ func HandleMouseMsg(state *app.AppState, git g.Service, msg tea.MouseMsg) tea.Cm
 		state.Clamp()
 		return nil
 	}
+	if msg.Action == tea.MouseActionPress && msg.Button == tea.MouseButtonRight {
+		state.OpenFileMenuAt(msg.X, msg.Y)
+		state.Clamp()
+		return nil
+	}
 	if msg.Action == tea.MouseActionPress && msg.Button == tea.MouseButtonWheelUp {
+		if state.OpOutputWheel(-1) || state.ScrollDialog(-1) || state.ConflictWheel(-1) || state.CommitDetailWheelAt(msg.X, msg.Y, -1) {
+			state.Clamp()
+			return nil
+		}
 		if state.BranchCreateWheelAt(msg.X, msg.Y, -1) {
 			state.Clamp()
 			return nil
@@ -71,6 +102,10 @@ func HandleMouseMsg(state *app.AppState, git g.Service, msg tea.MouseMsg) tea.Cm
 		return nil
 	}
 	if msg.Action == tea.MouseActionPress && msg.Button == tea.MouseButtonWheelDown {
+		if state.OpOutputWheel(1) || state.ScrollDialog(1) || state.ConflictWheel(1) || state.CommitDetailWheelAt(msg.X, msg.Y, 1) {
+			state.Clamp()
+			return nil
+		}
 		if state.BranchCreateWheelAt(msg.X, msg.Y, 1) {
 			state.Clamp()
 			return nil
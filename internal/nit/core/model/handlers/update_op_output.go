@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/zGIKS/nit/internal/nit/app"
+	"github.com/zGIKS/nit/internal/nit/core/model/cmds"
+	"github.com/zGIKS/nit/internal/nit/core/model/common"
+	g "github.com/zGIKS/nit/internal/nit/git"
+)
+
+func handleOpOutputKey(state *app.AppState, git g.Service, msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "esc", "enter":
+		state.CloseOpOutput()
+	case "n":
+		state.ConfirmRetryWithoutHooks()
+	case "pgup":
+		state.ScrollOpOutput(-10)
+	case "pgdown":
+		state.ScrollOpOutput(10)
+	default:
+		switch action := state.Keys.Match(msg.String()); action {
+		case app.ActionQuit:
+			if msg.Type == tea.KeyCtrlC {
+				return cmds.HandleResult(git, state.Apply(action))
+			}
+			state.CloseOpOutput()
+		case app.ActionMoveUp:
+			state.ScrollOpOutput(-1)
+		case app.ActionMoveDown:
+			state.ScrollOpOutput(1)
+		}
+	}
+	state.Clamp()
+	return nil
+}
+
+func HandleOpStarted(state *app.AppState, msg common.OpStartedMsg) tea.Cmd {
+	state.BeginOpOutput(msg.Stream.ID, msg.Op, msg.Hooks)
+	state.Clamp()
+	return cmds.WaitOpStreamCmd(msg.Stream)
+}
+
+func HandleOpOutput(state *app.AppState, msg common.OpOutputMsg) tea.Cmd {
+	if msg.Progress != nil {
+		state.SetOpProgress(msg.Stream.ID, *msg.Progress)
+	} else {
+		state.AppendOpOutput(msg.Stream.ID, msg.Line)
+	}
+	return cmds.WaitOpStreamCmd(msg.Stream)
+}
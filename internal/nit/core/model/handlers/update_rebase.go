@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/zGIKS/nit/internal/nit/app"
+	"github.com/zGIKS/nit/internal/nit/core/model/cmds"
+	"github.com/zGIKS/nit/internal/nit/core/model/common"
+	g "github.com/zGIKS/nit/internal/nit/git"
+)
+
+// rebaseActionKeys set the action of the todo item under the cursor. They are
+// only read while the rebase editor is open.
+var rebaseActionKeys = map[string]g.RebaseAction{
+	"p": g.RebasePick,
+	"r": g.RebaseReword,
+	"e": g.RebaseEdit,
+	"s": g.RebaseSquash,
+	"f": g.RebaseFixup,
+	"d": g.RebaseDrop,
+}
+
+func handleRebaseKey(state *app.AppState, git g.Service, msg tea.KeyMsg) tea.Cmd {
+	if msg.Type == tea.KeyEsc {
+		state.CloseRebaseEditor()
+		state.Clamp()
+		return nil
+	}
+	if action, ok := rebaseActionKeys[msg.String()]; ok {
+		state.SetRebaseAction(action)
+		state.Clamp()
+		return nil
+	}
+	switch msg.String() {
+	case "K", "shift+up":
+		state.MoveRebaseItem(-1)
+		state.Clamp()
+		return nil
+	case "J", "shift+down":
+		state.MoveRebaseItem(1)
+		state.Clamp()
+		return nil
+	}
+	switch action := state.Keys.Match(msg.String()); action {
+	case app.ActionQuit:
+		if msg.Type == tea.KeyCtrlC {
+			return cmds.HandleResult(git, state.Apply(action))
+		}
+		state.CloseRebaseEditor()
+	case app.ActionMoveUp:
+		state.MoveRebaseCursor(-1)
+	case app.ActionMoveDown:
+		state.MoveRebaseCursor(1)
+	case app.ActionToggleOne:
+		result := state.StartRebase()
+		state.Clamp()
+		return cmds.HandleResult(git, result)
+	}
+	state.Clamp()
+	return nil
+}
+
+func SyncRebaseTodo(state *app.AppState, git g.Service) tea.Cmd {
+	base, ok := state.RebaseTodoTarget()
+	if !ok {
+		return nil
+	}
+	state.BeginRebaseTodoLoad()
+	return cmds.LoadRebaseTodoCmd(git, base)
+}
+
+func HandleRebaseTodoLoaded(state *app.AppState, msg common.RebaseTodoLoadedMsg) tea.Cmd {
+	if !state.Rebase.Open || state.Rebase.Base != msg.Base {
+		return nil
+	}
+	if msg.Err != nil {
+		state.CloseRebaseEditor()
+		state.SetError(msg.Err.Error())
+		state.Clamp()
+		return nil
+	}
+	if len(msg.Items) == 0 {
+		base := state.Rebase.BaseShort
+		state.CloseRebaseEditor()
+		state.SetError("nothing to rebase after " + base)
+		state.Clamp()
+		return nil
+	}
+	state.SetRebaseTodo(msg.Base, msg.Items)
+	state.Clamp()
+	return nil
+}
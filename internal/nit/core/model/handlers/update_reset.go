@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/zGIKS/nit/internal/nit/app"
+	"github.com/zGIKS/nit/internal/nit/core/model/cmds"
+	"github.com/zGIKS/nit/internal/nit/core/model/common"
+	g "github.com/zGIKS/nit/internal/nit/git"
+)
+
+func handleResetKey(state *app.AppState, git g.Service, msg tea.KeyMsg) tea.Cmd {
+	if msg.Type == tea.KeyEsc {
+		state.BackReset()
+		state.Clamp()
+		return nil
+	}
+	switch action := state.Keys.Match(msg.String()); action {
+	case app.ActionQuit:
+		if msg.Type == tea.KeyCtrlC {
+			return cmds.HandleResult(git, state.Apply(action))
+		}
+		state.BackReset()
+	case app.ActionMoveUp:
+		state.MoveResetCursor(-1)
+	case app.ActionMoveDown:
+		state.MoveResetCursor(1)
+	case app.ActionToggleOne:
+		result := state.ConfirmReset()
+		state.Clamp()
+		return cmds.HandleResult(git, result)
+	}
+	state.Clamp()
+	return nil
+}
+
+func SyncResetPreview(state *app.AppState, git g.Service) tea.Cmd {
+	hash, ok := state.ResetPreviewTarget()
+	if !ok {
+		return nil
+	}
+	state.BeginResetPreviewLoad()
+	return cmds.LoadResetPreviewCmd(git, hash)
+}
+
+func HandleResetPreviewLoaded(state *app.AppState, msg common.ResetPreviewLoadedMsg) tea.Cmd {
+	if !state.Reset.Open || state.Reset.Hash != msg.Hash {
+		return nil
+	}
+	if msg.Err != nil {
+		state.CloseReset()
+		state.SetError(msg.Err.Error())
+		state.Clamp()
+		return nil
+	}
+	state.SetResetPreview(msg.Hash, msg.Preview)
+	state.Clamp()
+	return nil
+}
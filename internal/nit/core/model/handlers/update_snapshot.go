@@ -0,0 +1,28 @@
+package handlers
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/zGIKS/nit/internal/nit/app"
+	"github.com/zGIKS/nit/internal/nit/core/model/cmds"
+	"github.com/zGIKS/nit/internal/nit/core/model/common"
+	g "github.com/zGIKS/nit/internal/nit/git"
+)
+
+func SyncSnapshots(state *app.AppState, git g.Service) tea.Cmd {
+	if !state.SnapshotsWanted() {
+		return nil
+	}
+	state.BeginSnapshotsLoad()
+	return cmds.LoadSnapshotsCmd(git)
+}
+
+func HandleSnapshotsLoaded(state *app.AppState, msg common.SnapshotsLoadedMsg) tea.Cmd {
+	if msg.Err != nil {
+		state.SnapshotsFailed(msg.Err)
+		state.Clamp()
+		return nil
+	}
+	state.SetSnapshots(msg.Snapshots)
+	state.Clamp()
+	return nil
+}
@@ -1,8 +1,6 @@
 package model
 
 import (
-	"time"
-
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/zGIKS/nit/internal/nit/app"
 	"github.com/zGIKS/nit/internal/nit/config"
@@ -33,8 +31,8 @@ func New() Model {
 		cfg.UI.BranchCreateNameLabel,
 		cfg.UI.BranchCreateSourceLabel,
 	)
-	state.SetGraph([]string{"Loading graph..."})
-	state.SetBranches([]string{"Loading branches..."})
+	state.SetCommitConventions(cfg.Commit.Conventional, cfg.Commit.Types, cfg.Commit.Trailers)
+	state.SetConfirmTypeYes(cfg.Confirm.TypeYes)
 	state.SetChanges(nil)
 	if keyErr != "" {
 		state.SetError(keyErr)
@@ -42,8 +40,18 @@ func New() Model {
 		state.SetError(cfgWarn)
 	}
 
-	runner := g.NewRunner(4 * time.Second)
-	svc := g.NewService(runner)
+	runner := g.NewRunner(g.RunnerOptions{
+		ReadTimeout:  cfg.Git.ReadTimeout,
+		WriteTimeout: cfg.Git.WriteTimeout,
+		GitPath:      cfg.Git.Path,
+		Options:      cfg.Git.Options,
+		Env:          cfg.Git.Env,
+	})
+	svc := g.NewService(runner).
+		WithGraphSignatures(cfg.Commit.VerifySignatures).
+		WithFetchTimeout(cfg.Remote.FetchTimeout).
+		WithPullTimeout(cfg.Remote.PullTimeout).
+		WithPushTimeout(cfg.Remote.PushTimeout)
 
 	return Model{
 		State:    state,
@@ -60,7 +68,10 @@ func (m Model) Init() tea.Cmd {
 		cmds.LoadChangesCmd(m.Git),
 		cmds.LoadGraphCmd(m.Git),
 		cmds.LoadBranchesCmd(m.Git),
+		cmds.LoadStashesCmd(m.Git),
 		cmds.LoadRepoSummaryCmd(m.Git),
+		cmds.LoadCommitTemplateCmd(m.Git),
+		cmds.LoadSigningConfigCmd(m.Git),
 		cmds.InitWatchCmd(m.Git),
 	)
 }
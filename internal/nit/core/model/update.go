@@ -20,7 +20,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, tea.Batch(cmds.ScheduleChangesPoll(), cmds.LoadChangesCmd(m.Git))
 
 	case common.GraphPollMsg:
-		return m, tea.Batch(cmds.ScheduleGraphPoll(), cmds.LoadGraphCmd(m.Git), cmds.LoadBranchesCmd(m.Git), cmds.LoadRepoSummaryCmd(m.Git))
+		return m, tea.Batch(cmds.ScheduleGraphPoll(), cmds.LoadGraphCmd(m.Git), cmds.LoadBranchesCmd(m.Git), cmds.LoadStashesCmd(m.Git), cmds.LoadRepoSummaryCmd(m.Git))
 
 	case common.WatchReadyMsg:
 		if msg.Err != nil {
@@ -36,11 +36,22 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			cmds.WaitWatchCmd(m.Watcher),
 			cmds.LoadChangesCmd(m.Git),
 			cmds.LoadBranchesCmd(m.Git),
+			cmds.LoadStashesCmd(m.Git),
 			cmds.LoadRepoSummaryCmd(m.Git),
 		)
 
 	case common.ChangesLoadedMsg:
-		return m, handlers.HandleChangesLoaded(&m.State, msg)
+		return m, handlers.HandleChangesLoaded(&m.State, m.Git, msg)
+
+	case common.DiffLoadedMsg:
+		return m, handlers.HandleDiffLoaded(&m.State, msg)
+
+	case common.CommitDetailLoadedMsg:
+		cmd := handlers.HandleCommitDetailLoaded(&m.State, msg)
+		return m, tea.Batch(cmd, handlers.SyncCommitFileDiff(&m.State, m.Git), handlers.SyncRebaseTodo(&m.State, m.Git))
+
+	case common.CommitFileDiffLoadedMsg:
+		return m, handlers.HandleCommitFileDiffLoaded(&m.State, msg)
 
 	case common.GraphLoadedMsg:
 		return m, handlers.HandleGraphLoaded(&m.State, msg)
@@ -48,17 +59,69 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case common.BranchesLoadedMsg:
 		return m, handlers.HandleBranchesLoaded(&m.State, msg)
 
+	case common.StashesLoadedMsg:
+		return m, handlers.HandleStashesLoaded(&m.State, msg)
+
 	case common.RepoSummaryLoadedMsg:
 		return m, handlers.HandleRepoSummaryLoaded(&m.State, msg)
 
+	case common.SigningConfigLoadedMsg:
+		return m, handlers.HandleSigningConfigLoaded(&m.State, msg)
+
+	case common.AmendCommitLoadedMsg:
+		return m, handlers.HandleAmendCommitLoaded(&m.State, msg)
+
+	case common.RebaseTodoLoadedMsg:
+		return m, handlers.HandleRebaseTodoLoaded(&m.State, msg)
+
+	case common.ConflictLoadedMsg:
+		return m, handlers.HandleConflictLoaded(&m.State, msg)
+
+	case common.ResetPreviewLoadedMsg:
+		return m, handlers.HandleResetPreviewLoaded(&m.State, msg)
+
+	case common.SnapshotsLoadedMsg:
+		return m, handlers.HandleSnapshotsLoaded(&m.State, msg)
+
+	case common.FileHistoryLoadedMsg:
+		return m, handlers.HandleFileHistoryLoaded(&m.State, msg)
+
+	case common.EditorReadyMsg:
+		return m, handlers.HandleEditorReady(&m.State, msg)
+
+	case common.EditorClosedMsg:
+		return m, handlers.HandleEditorClosed(&m.State, m.Git, msg)
+
+	case common.CommitMessageEditedMsg:
+		return m, handlers.HandleCommitMessageEdited(&m.State, msg)
+
+	case common.UnmergedCommitsLoadedMsg:
+		return m, handlers.HandleUnmergedCommitsLoaded(&m.State, msg)
+
+	case common.SwitchBlockedMsg:
+		return m, handlers.HandleSwitchBlocked(&m.State, msg)
+
+	case common.OpStartedMsg:
+		return m, handlers.HandleOpStarted(&m.State, msg)
+
+	case common.OpOutputMsg:
+		return m, handlers.HandleOpOutput(&m.State, msg)
+
 	case common.OpDoneMsg:
 		return m, handlers.HandleOpDone(&m.State, m.Git, msg)
 
+	case common.CommitTemplateLoadedMsg:
+		return m, handlers.HandleCommitTemplateLoaded(&m.State, msg)
+
 	case tea.KeyMsg:
-		return m, handlers.HandleKeyMsg(&m.State, m.Git, m.ClipCfg, m.TextKeys, &m.PasteHintAlreadySeen, msg)
+		cmd := handlers.HandleKeyMsg(&m.State, m.Git, m.ClipCfg, m.TextKeys, &m.PasteHintAlreadySeen, msg)
+		m.State.SyncCommitTemplate()
+		return m, tea.Batch(cmd, handlers.SyncDiff(&m.State, m.Git, false), handlers.SyncCommitFileDiff(&m.State, m.Git), handlers.SyncRebaseTodo(&m.State, m.Git), handlers.SyncConflict(&m.State, m.Git), handlers.SyncResetPreview(&m.State, m.Git), handlers.SyncConfirmUnmerged(&m.State, m.Git), handlers.SyncSnapshots(&m.State, m.Git), handlers.SyncFileHistory(&m.State, m.Git), handlers.SyncCommitDetail(&m.State, m.Git), handlers.SyncEditor(&m.State, m.Git), handlers.SyncCopyPath(&m.State, m.ClipCfg), handlers.SyncAmendMessage(&m.State, m.Git))
 
 	case tea.MouseMsg:
-		return m, handlers.HandleMouseMsg(&m.State, m.Git, msg)
+		cmd := handlers.HandleMouseMsg(&m.State, m.Git, msg)
+		m.State.SyncCommitTemplate()
+		return m, tea.Batch(cmd, handlers.SyncDiff(&m.State, m.Git, false), handlers.SyncCommitFileDiff(&m.State, m.Git), handlers.SyncRebaseTodo(&m.State, m.Git), handlers.SyncConflict(&m.State, m.Git), handlers.SyncResetPreview(&m.State, m.Git), handlers.SyncConfirmUnmerged(&m.State, m.Git), handlers.SyncSnapshots(&m.State, m.Git), handlers.SyncFileHistory(&m.State, m.Git), handlers.SyncCommitDetail(&m.State, m.Git), handlers.SyncEditor(&m.State, m.Git), handlers.SyncCopyPath(&m.State, m.ClipCfg), handlers.SyncAmendMessage(&m.State, m.Git))
 	}
 
 	return m, nil
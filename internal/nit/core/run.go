@@ -1,24 +1,20 @@
 package core
 
 import (
-	"os"
-	"strings"
-
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/zGIKS/nit/internal/nit/core/model"
+	"github.com/zGIKS/nit/internal/nit/core/model/common"
 )
 
 func Run() error {
 	opts := []tea.ProgramOption{tea.WithAltScreen()}
-	switch strings.ToLower(strings.TrimSpace(os.Getenv("NIT_MOUSE_MODE"))) {
-	case "", "cell":
+	switch common.MouseMode() {
+	case "cell":
 		opts = append(opts, tea.WithMouseCellMotion())
 	case "all":
 		opts = append(opts, tea.WithMouseAllMotion())
 	case "off":
 		// Mouse disabled for terminals with incompatible mouse tracking support.
-	default:
-		opts = append(opts, tea.WithMouseCellMotion())
 	}
 	p := tea.NewProgram(model.New(), opts...)
 	_, err := p.Run()
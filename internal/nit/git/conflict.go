@@ -0,0 +1,92 @@
+package git
+
+import "strings"
+
+// ParseConflicts finds the conflict blocks git wrote into a file. A block
+// that is not closed by a ">>>>>>>" marker is left as plain text.
+func ParseConflicts(path, content string) ConflictFile {
+	f := ConflictFile{Path: path, Lines: strings.Split(content, "\n")}
+	for i := 0; i < len(f.Lines); i++ {
+		label, ok := conflictMarker(f.Lines[i], '<')
+		if !ok {
+			continue
+		}
+		h, ok := parseConflictHunk(f.Lines, i, label)
+		if !ok {
+			continue
+		}
+		f.Hunks = append(f.Hunks, h)
+		i = h.End
+	}
+	return f
+}
+
+func parseConflictHunk(lines []string, start int, oursLabel string) (ConflictHunk, bool) {
+	h := ConflictHunk{Start: start, OursLabel: oursLabel}
+	side := &h.Ours
+	for i := start + 1; i < len(lines); i++ {
+		line := lines[i]
+		if _, ok := conflictMarker(line, '<'); ok {
+			return ConflictHunk{}, false
+		}
+		if _, ok := conflictMarker(line, '|'); ok && side == &h.Ours {
+			side = &h.Base
+			continue
+		}
+		if strings.TrimRight(line, "\r") == "=======" && side != &h.Theirs {
+			h.Separator = i
+			side = &h.Theirs
+			continue
+		}
+		if label, ok := conflictMarker(line, '>'); ok && side == &h.Theirs {
+			h.End = i
+			h.TheirsLabel = label
+			return h, true
+		}
+		*side = append(*side, line)
+	}
+	return ConflictHunk{}, false
+}
+
+// conflictMarker reports whether line is a conflict marker made of seven c
+// characters, and returns the label that follows it.
+func conflictMarker(line string, c byte) (string, bool) {
+	line = strings.TrimRight(line, "\r")
+	marker := strings.Repeat(string(c), 7)
+	if !strings.HasPrefix(line, marker) {
+		return "", false
+	}
+	rest := line[len(marker):]
+	if rest != "" && rest[0] != ' ' {
+		return "", false
+	}
+	return strings.TrimSpace(rest), true
+}
+
+// Resolve replaces every conflict block with the side chosen for it. Both
+// keeps our lines followed by theirs. It reports false while a block has no
+// choice.
+func (f ConflictFile) Resolve(choices []ConflictChoice) (string, bool) {
+	if len(choices) != len(f.Hunks) {
+		return "", false
+	}
+	out := make([]string, 0, len(f.Lines))
+	next := 0
+	for i, h := range f.Hunks {
+		out = append(out, f.Lines[next:h.Start]...)
+		switch choices[i] {
+		case ConflictOurs:
+			out = append(out, h.Ours...)
+		case ConflictTheirs:
+			out = append(out, h.Theirs...)
+		case ConflictBoth:
+			out = append(out, h.Ours...)
+			out = append(out, h.Theirs...)
+		default:
+			return "", false
+		}
+		next = h.End + 1
+	}
+	out = append(out, f.Lines[next:]...)
+	return strings.Join(out, "\n"), true
+}
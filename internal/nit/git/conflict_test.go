@@ -0,0 +1,53 @@
+package git
+
+import "testing"
+
+func TestParseConflicts(t *testing.T) {
+	content := "top\n" +
+		"<<<<<<< HEAD\n" +
+		"ours\n" +
+		"||||||| base\n" +
+		"base\n" +
+		"=======\n" +
+		"theirs 1\n" +
+		"theirs 2\n" +
+		">>>>>>> feature\n" +
+		"middle\n" +
+		"<<<<<<< HEAD\n" +
+		"=======\n" +
+		"added\n" +
+		">>>>>>> feature\n" +
+		"<<<<<<< not closed\n" +
+		"bottom\n"
+	f := ParseConflicts("a.txt", content)
+	if len(f.Hunks) != 2 {
+		t.Fatalf("hunks = %d, want 2", len(f.Hunks))
+	}
+	h := f.Hunks[0]
+	if h.Start != 1 || h.Separator != 5 || h.End != 8 || h.OursLabel != "HEAD" || h.TheirsLabel != "feature" {
+		t.Fatalf("first hunk = %+v", h)
+	}
+	if len(h.Ours) != 1 || len(h.Base) != 1 || len(h.Theirs) != 2 {
+		t.Fatalf("first hunk sides = %q %q %q", h.Ours, h.Base, h.Theirs)
+	}
+	if h := f.Hunks[1]; len(h.Ours) != 0 || len(h.Theirs) != 1 {
+		t.Fatalf("second hunk sides = %q %q", h.Ours, h.Theirs)
+	}
+
+	if _, ok := f.Resolve([]ConflictChoice{ConflictOurs}); ok {
+		t.Fatal("resolved with a missing choice")
+	}
+	got, ok := f.Resolve([]ConflictChoice{ConflictBoth, ConflictTheirs})
+	want := "top\nours\ntheirs 1\ntheirs 2\nmiddle\nadded\n<<<<<<< not closed\nbottom\n"
+	if !ok || got != want {
+		t.Fatalf("Resolve = %q, %v; want %q", got, ok, want)
+	}
+}
+
+func TestParseChangeLineConflicted(t *testing.T) {
+	for raw, want := range map[string]bool{"UU a": true, "AA a": true, "DU a": true, "M  a": false, "?? a": false} {
+		if got := ParseChangeLine(raw).Conflicted; got != want {
+			t.Errorf("ParseChangeLine(%q).Conflicted = %v, want %v", raw, got, want)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package git
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var hunkHeaderRe = regexp.MustCompile(`^@@ -(\d+)(?:,(\d+))? \+(\d+)(?:,(\d+))? @@`)
+
+func ParseDiff(path string, staged bool, raw string) FileDiff {
+	d := FileDiff{Path: path, Staged: staged}
+	if strings.TrimSpace(raw) == "" {
+		return d
+	}
+	for _, line := range strings.Split(raw, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if strings.HasPrefix(line, "@@") {
+			d.Hunks = append(d.Hunks, parseHunkHeader(line))
+			continue
+		}
+		if len(d.Hunks) == 0 {
+			if strings.HasPrefix(line, "Binary files ") || strings.HasPrefix(line, "GIT binary patch") {
+				d.Binary = true
+			}
+			d.Header = append(d.Header, line)
+			continue
+		}
+		h := &d.Hunks[len(d.Hunks)-1]
+		if line == "" {
+			// Every empty line inside a hunk is kept as the context line " ",
+			// so that patches built from the hunk stay valid. None comes
+			// from the end of the output, which the runner trims.
+			line = " "
+		}
+		h.Lines = append(h.Lines, line)
+	}
+	return d
+}
+
+func parseHunkHeader(line string) DiffHunk {
+	h := DiffHunk{Header: line, OldCount: 1, NewCount: 1}
+	m := hunkHeaderRe.FindStringSubmatch(line)
+	if m == nil {
+		return h
+	}
+	h.OldStart, _ = strconv.Atoi(m[1])
+	if m[2] != "" {
+		h.OldCount, _ = strconv.Atoi(m[2])
+	}
+	h.NewStart, _ = strconv.Atoi(m[3])
+	if m[4] != "" {
+		h.NewCount, _ = strconv.Atoi(m[4])
+	}
+	return h
+}
+
+// Lines flattens the diff into display rows, keeping a back-reference to the
+// hunk and hunk line every row came from.
+func (d FileDiff) Lines() []DiffLine {
+	out := make([]DiffLine, 0, len(d.Header)+len(d.Hunks)*8)
+	for _, line := range d.Header {
+		out = append(out, DiffLine{Kind: DiffLineHeader, Text: line, Hunk: -1, Line: -1})
+	}
+	for hi, h := range d.Hunks {
+		out = append(out, DiffLine{Kind: DiffLineHunk, Text: h.Header, Hunk: hi, Line: -1})
+		for li, line := range h.Lines {
+			out = append(out, DiffLine{Kind: diffLineKind(line), Text: line, Hunk: hi, Line: li})
+		}
+	}
+	return out
+}
+
+// FirstChangedLine is the line of the new file where the first change of the
+// diff is, or 0 when the diff has no changed lines. A deletion counts as the
+// line that now follows it.
+func (d FileDiff) FirstChangedLine() int {
+	for _, h := range d.Hunks {
+		n := h.NewStart
+		for _, line := range h.Lines {
+			switch diffLineKind(line) {
+			case DiffLineAdded, DiffLineRemoved:
+				return max(1, n)
+			case DiffLineContext:
+				n++
+			}
+		}
+	}
+	return 0
+}
+
+func diffLineKind(line string) DiffLineKind {
+	if line == "" {
+		return DiffLineContext
+	}
+	switch line[0] {
+	case '+':
+		return DiffLineAdded
+	case '-':
+		return DiffLineRemoved
+	case '\\':
+		return DiffLineNote
+	default:
+		return DiffLineContext
+	}
+}
@@ -0,0 +1,127 @@
+package git
+
+import "testing"
+
+func TestParseDiff(t *testing.T) {
+	raw := "diff --git a/a.txt b/a.txt\n" +
+		"index 1111111..2222222 100644\n" +
+		"--- a/a.txt\n" +
+		"+++ b/a.txt\n" +
+		"@@ -1,3 +1,3 @@ header\n" +
+		" one\n" +
+		"-two\n" +
+		"+TWO\n" +
+		"\n" +
+		"@@ -10 +10,2 @@\n" +
+		" ten\n" +
+		"+eleven"
+	d := ParseDiff("a.txt", true, raw)
+	if len(d.Header) != 4 {
+		t.Fatalf("header lines = %d, want 4", len(d.Header))
+	}
+	if len(d.Hunks) != 2 {
+		t.Fatalf("hunks = %d, want 2", len(d.Hunks))
+	}
+	h := d.Hunks[0]
+	if h.OldStart != 1 || h.OldCount != 3 || h.NewStart != 1 || h.NewCount != 3 {
+		t.Fatalf("first hunk range = %+v", h)
+	}
+	if got := h.Lines[len(h.Lines)-1]; got != " " {
+		t.Fatalf("blank context line = %q, want %q", got, " ")
+	}
+	h = d.Hunks[1]
+	if h.OldStart != 10 || h.OldCount != 1 || h.NewStart != 10 || h.NewCount != 2 {
+		t.Fatalf("second hunk range = %+v", h)
+	}
+
+	lines := d.Lines()
+	if len(lines) != 4+1+4+1+2 {
+		t.Fatalf("flattened lines = %d", len(lines))
+	}
+	if l := lines[6]; l.Kind != DiffLineRemoved || l.Hunk != 0 || l.Line != 1 {
+		t.Fatalf("lines[6] = %+v, want removed line 1 of hunk 0", l)
+	}
+	if l := lines[9]; l.Kind != DiffLineHunk || l.Hunk != 1 || l.Line != -1 {
+		t.Fatalf("lines[9] = %+v, want header of hunk 1", l)
+	}
+}
+
+func TestParseDiffBinary(t *testing.T) {
+	d := ParseDiff("img.png", false, "diff --git a/img.png b/img.png\nBinary files a/img.png and b/img.png differ")
+	if !d.Binary || len(d.Hunks) != 0 {
+		t.Fatalf("ParseDiff binary = %+v", d)
+	}
+}
+
+func TestFileDiffFirstChangedLine(t *testing.T) {
+	cases := []struct {
+		name string
+		raw  string
+		want int
+	}{
+		{"after context", "@@ -3,4 +3,4 @@\n three\n four\n-five\n+FIVE\n six", 5},
+		{"deletion at top", "@@ -1,2 +0,0 @@\n-one\n-two", 1},
+		{"new file", "@@ -0,0 +1,2 @@\n+one\n+two", 1},
+		{"no changes", "diff --git a/a.txt b/a.txt\nold mode 100644\nnew mode 100755", 0},
+	}
+	for _, c := range cases {
+		if got := ParseDiff("a.txt", false, c.raw).FirstChangedLine(); got != c.want {
+			t.Errorf("%s: FirstChangedLine() = %d, want %d", c.name, got, c.want)
+		}
+	}
+}
+
+func TestPatchSelectedLines(t *testing.T) {
+	d := ParseDiff("a.txt", false, "diff --git a/a.txt b/a.txt\n"+
+		"--- a/a.txt\n"+
+		"+++ b/a.txt\n"+
+		"@@ -1,3 +1,3 @@\n"+
+		" one\n"+
+		"-two\n"+
+		"+TWO\n"+
+		" three\n"+
+		"@@ -8,2 +8,3 @@\n"+
+		" eight\n"+
+		"+eight and a half\n"+
+		" nine")
+
+	onlyAdd := func(hunk, line int) bool { return hunk == 0 && line == 2 }
+	want := "diff --git a/a.txt b/a.txt\n" +
+		"--- a/a.txt\n" +
+		"+++ b/a.txt\n" +
+		"@@ -1,3 +1,4 @@\n" +
+		" one\n" +
+		" two\n" +
+		"+TWO\n" +
+		" three\n"
+	if got := d.Patch(onlyAdd, false); got != want {
+		t.Fatalf("forward patch:\n%s\nwant:\n%s", got, want)
+	}
+
+	want = "diff --git a/a.txt b/a.txt\n" +
+		"--- a/a.txt\n" +
+		"+++ b/a.txt\n" +
+		"@@ -1,2 +1,3 @@\n" +
+		" one\n" +
+		"+TWO\n" +
+		" three\n"
+	if got := d.Patch(onlyAdd, true); got != want {
+		t.Fatalf("reverse patch:\n%s\nwant:\n%s", got, want)
+	}
+
+	secondHunk := func(hunk, line int) bool { return hunk == 1 }
+	want = "diff --git a/a.txt b/a.txt\n" +
+		"--- a/a.txt\n" +
+		"+++ b/a.txt\n" +
+		"@@ -8,2 +8,3 @@\n" +
+		" eight\n" +
+		"+eight and a half\n" +
+		" nine\n"
+	if got := d.Patch(secondHunk, false); got != want {
+		t.Fatalf("hunk patch:\n%s\nwant:\n%s", got, want)
+	}
+
+	if got := d.Patch(func(int, int) bool { return false }, false); got != "" {
+		t.Fatalf("empty selection patch = %q, want empty", got)
+	}
+}
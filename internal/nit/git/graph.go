@@ -1,47 +1,219 @@
 package git
 
 import (
-	"regexp"
 	"strings"
+	"time"
 )
 
-var graphHashRe = regexp.MustCompile(`[0-9a-f]{7,40}\b`)
-var graphPrefixRe = regexp.MustCompile(`^[|\\/*_. ]+`)
-
-func prettifyGraphLine(line string) string {
-	if line == "" {
-		return line
-	}
-	prefixEnd := 0
-	if loc := graphHashRe.FindStringIndex(line); loc != nil && loc[0] > 0 {
-		prefixEnd = loc[0]
-	} else if loc := graphPrefixRe.FindStringIndex(line); loc != nil {
-		prefixEnd = loc[1]
-	}
-	if prefixEnd <= 0 {
-		return line
-	}
-	return replaceGraphChars(line[:prefixEnd]) + line[prefixEnd:]
-}
-
-func replaceGraphChars(s string) string {
-	var b strings.Builder
-	b.Grow(len(s))
-	for _, r := range s {
-		switch r {
-		case '|':
-			b.WriteRune('│')
-		case '/':
-			b.WriteRune('╱')
-		case '\\':
-			b.WriteRune('╲')
-		case '*':
-			b.WriteRune('●')
-		case '_':
-			b.WriteRune('─')
+// graphLogFormat prints one commit per line with NUL separated fields; the
+// subject comes last and never contains a newline. The signature field is
+// left empty unless signatures are verified, since %G? runs the signing
+// program for every signed commit.
+const (
+	graphLogFormat           = "--format=%H%x00%h%x00%P%x00%D%x00%an%x00%aI%x00%x00%s"
+	graphLogFormatSignatures = "--format=%H%x00%h%x00%P%x00%D%x00%an%x00%aI%x00%G?%x00%s"
+)
+
+func parseGraphLog(raw string) []Commit {
+	commits := []Commit{}
+	for _, line := range strings.Split(raw, "\n") {
+		f := strings.SplitN(line, "\x00", 8)
+		if len(f) < 8 {
+			continue
+		}
+		c := Commit{
+			Hash:      f[0],
+			ShortHash: f[1],
+			Parents:   strings.Fields(f[2]),
+			Author:    f[4],
+			Signature: parseSignatureStatus(f[6]),
+			Subject:   f[7],
+		}
+		if f[3] != "" {
+			c.Refs = strings.Split(f[3], ", ")
+		}
+		c.Date, _ = time.Parse(time.RFC3339, f[5])
+		commits = append(commits, c)
+	}
+	return commits
+}
+
+// GraphLines renders one display row per commit: the graph columns followed
+// by the short hash, refs and subject.
+func GraphLines(commits []Commit) []string {
+	graph := layoutGraph(commits)
+	lines := make([]string, 0, len(commits))
+	for i, c := range commits {
+		line := graph[i] + " " + c.ShortHash
+		if badge := SignatureBadge(c.Signature); badge != "" {
+			line += " " + badge
+		}
+		if len(c.Refs) > 0 {
+			line += " (" + strings.Join(c.Refs, ", ") + ")"
+		}
+		lines = append(lines, line+" "+c.Subject)
+	}
+	return lines
+}
+
+// parseSignatureStatus maps a %G? code onto a SignatureStatus.
+func parseSignatureStatus(code string) SignatureStatus {
+	switch code {
+	case "":
+		return ""
+	case "G":
+		return SignatureGood
+	case "B", "R":
+		return SignatureBad
+	case "N":
+		return SignatureNone
+	default:
+		// U, X, Y and E: signed, but the key is untrusted, expired or
+		// missing.
+		return SignatureUnknown
+	}
+}
+
+// SignatureBadge is the mark shown after a commit's short hash in the graph,
+// or "" when the signature was not checked.
+func SignatureBadge(status SignatureStatus) string {
+	switch status {
+	case SignatureGood:
+		return "✓"
+	case SignatureBad:
+		return "✗"
+	case SignatureUnknown:
+		return "?"
+	case SignatureNone:
+		return "·"
+	}
+	return ""
+}
+
+// layoutGraph assigns every commit a column and draws the edges to its
+// parents. commits must be newest first in topological order, as
+// "git log --topo-order" prints them. Each column is two cells wide: the lane
+// itself and a gap that carries horizontal edges.
+func layoutGraph(commits []Commit) []string {
+	// lanes holds, per column, the hash of the commit the column leads to;
+	// an empty string is a free column.
+	var lanes []string
+	rows := make([]string, 0, len(commits))
+	for _, c := range commits {
+		col := laneIndex(lanes, c.Hash)
+		if col < 0 {
+			col = freeLane(&lanes)
+		}
+		before := append([]string(nil), lanes...)
+
+		edges := map[int]bool{}
+		for i, h := range lanes {
+			if h == c.Hash && i != col {
+				// Another child reached this commit first; its lane ends here.
+				lanes[i] = ""
+				edges[i] = true
+			}
+		}
+		lanes[col] = ""
+		if len(c.Parents) > 0 {
+			lanes[col] = c.Parents[0]
+		}
+		for _, p := range c.Parents[min(1, len(c.Parents)):] {
+			k := laneIndex(lanes, p)
+			if k < 0 {
+				k = freeLane(&lanes)
+				lanes[k] = p
+			}
+			edges[k] = true
+		}
+
+		rows = append(rows, graphRow(before, lanes, col, edges))
+		for len(lanes) > 0 && lanes[len(lanes)-1] == "" {
+			lanes = lanes[:len(lanes)-1]
+		}
+	}
+	return rows
+}
+
+func graphRow(before, after []string, col int, edges map[int]bool) string {
+	n := max(len(before), len(after))
+	lo, hi := col, col
+	for i := range edges {
+		lo, hi = min(lo, i), max(hi, i)
+	}
+	cells := make([]string, 2*n)
+	for i := 0; i < n; i++ {
+		above := i < len(before) && before[i] != ""
+		below := i < len(after) && after[i] != ""
+		cross := i > lo && i < hi
+		switch {
+		case i == col:
+			cells[2*i] = "●"
+		case edges[i] && cross:
+			cells[2*i] = graphJunction(above, below)
+		case edges[i]:
+			cells[2*i] = graphCorner(above, below, i > col)
+		case (above || below) && cross:
+			cells[2*i] = "┼"
+		case above || below:
+			cells[2*i] = "│"
+		case cross:
+			cells[2*i] = "─"
 		default:
-			b.WriteRune(r)
+			cells[2*i] = " "
+		}
+		cells[2*i+1] = " "
+		if i >= lo && i < hi {
+			cells[2*i+1] = "─"
+		}
+	}
+	return strings.TrimRight(strings.Join(cells, ""), " ")
+}
+
+func graphCorner(above, below, right bool) string {
+	switch {
+	case right && above && below:
+		return "┤"
+	case right && above:
+		return "╯"
+	case right:
+		return "╮"
+	case above && below:
+		return "├"
+	case above:
+		return "╰"
+	default:
+		return "╭"
+	}
+}
+
+// graphJunction draws an edge that meets a horizontal line passing through.
+func graphJunction(above, below bool) string {
+	switch {
+	case above && below:
+		return "┼"
+	case above:
+		return "┴"
+	default:
+		return "┬"
+	}
+}
+
+func laneIndex(lanes []string, hash string) int {
+	for i, h := range lanes {
+		if h == hash {
+			return i
+		}
+	}
+	return -1
+}
+
+func freeLane(lanes *[]string) int {
+	for i, h := range *lanes {
+		if h == "" {
+			return i
 		}
 	}
-	return b.String()
+	*lanes = append(*lanes, "")
+	return len(*lanes) - 1
 }
@@ -18,7 +18,28 @@ func ParseChangeLine(raw string) ChangeEntry {
 		path = strings.TrimSpace(parts[len(parts)-1])
 	}
 	e.Path = path
+	e.Conflicted = isConflictStatus(e.X, e.Y)
 	e.Staged = e.X != ' ' && e.X != '?'
 	e.Changed = e.Y != ' ' || e.X == '?'
 	return e
 }
+
+// OrigPath is the path a renamed or copied file had before, or "".
+func (e ChangeEntry) OrigPath() string {
+	if len(e.Raw) < 3 || !(e.X == 'R' || e.X == 'C' || e.Y == 'R' || e.Y == 'C') {
+		return ""
+	}
+	from, _, found := strings.Cut(strings.TrimSpace(e.Raw[3:]), " -> ")
+	if !found {
+		return ""
+	}
+	return strings.TrimSpace(from)
+}
+
+func isConflictStatus(x, y byte) bool {
+	switch string([]byte{x, y}) {
+	case "DD", "AU", "UD", "UA", "DU", "AA", "UU":
+		return true
+	}
+	return false
+}
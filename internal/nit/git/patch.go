@@ -0,0 +1,97 @@
+package git
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Patch builds a patch holding only the changed lines for which selected
+// returns true. Unselected changes are turned into context or dropped so the
+// result still applies: when reverse is false the patch is meant for a plain
+// "git apply" and unselected removals stay as context; when reverse is true
+// it is meant for "git apply -R" and unselected additions stay instead.
+// An empty string means no changed line was selected.
+func (d FileDiff) Patch(selected func(hunk, line int) bool, reverse bool) string {
+	if d.Binary || len(d.Hunks) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	delta := 0
+	for hi, h := range d.Hunks {
+		body := make([]string, 0, len(h.Lines))
+		oldCount, newCount := 0, 0
+		picked := false
+		kept := false
+		for li, line := range h.Lines {
+			kind := diffLineKind(line)
+			sel := selected(hi, li)
+			switch {
+			case kind == DiffLineNote:
+				if kept {
+					body = append(body, line)
+				}
+				continue
+			case kind == DiffLineContext:
+				body = append(body, line)
+				oldCount++
+				newCount++
+			case kind == DiffLineAdded && sel:
+				body = append(body, line)
+				newCount++
+				picked = true
+			case kind == DiffLineRemoved && sel:
+				body = append(body, line)
+				oldCount++
+				picked = true
+			case kind == DiffLineAdded && reverse, kind == DiffLineRemoved && !reverse:
+				body = append(body, " "+line[1:])
+				oldCount++
+				newCount++
+			default:
+				kept = false
+				continue
+			}
+			kept = true
+		}
+		if !picked {
+			continue
+		}
+		if b.Len() == 0 {
+			for _, line := range d.Header {
+				b.WriteString(line)
+				b.WriteByte('\n')
+			}
+		}
+		// Work with the number of lines preceding the hunk on each side;
+		// git writes a zero-length range as "line before" rather than
+		// "first line".
+		preOld, preNew := h.OldStart, h.NewStart
+		if h.OldCount > 0 {
+			preOld--
+		}
+		if h.NewCount > 0 {
+			preNew--
+		}
+		if reverse {
+			// Applied in reverse the new side is what is on disk or in the
+			// index, so it keeps its position and the old side moves.
+			preOld = preNew - delta
+		} else {
+			preNew = preOld + delta
+		}
+		oldStart, newStart := preOld, preNew
+		if oldCount > 0 {
+			oldStart++
+		}
+		if newCount > 0 {
+			newStart++
+		}
+		fmt.Fprintf(&b, "@@ -%d,%d +%d,%d @@\n", oldStart, oldCount, newStart, newCount)
+		for _, line := range body {
+			b.WriteString(line)
+			b.WriteByte('\n')
+		}
+		delta += newCount - oldCount
+	}
+	return b.String()
+}
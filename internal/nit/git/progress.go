@@ -0,0 +1,30 @@
+package git
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Progress is one progress report git prints with --progress, such as
+// "Receiving objects:  45% (450/1000)".
+type Progress struct {
+	Phase   string
+	Percent int
+	Done    int
+	Total   int
+}
+
+var progressRe = regexp.MustCompile(`^(?:remote: )?([A-Za-z][A-Za-z ]*):\s+(\d{1,3})% \((\d+)/(\d+)\)`)
+
+func parseProgress(line string) (Progress, bool) {
+	m := progressRe.FindStringSubmatch(strings.TrimSpace(line))
+	if m == nil {
+		return Progress{}, false
+	}
+	p := Progress{Phase: m[1]}
+	p.Percent, _ = strconv.Atoi(m[2])
+	p.Done, _ = strconv.Atoi(m[3])
+	p.Total, _ = strconv.Atoi(m[4])
+	return p, true
+}
@@ -3,39 +3,170 @@ package git
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"os"
 	"os/exec"
 	"strings"
+	"sync"
 	"time"
 )
 
+// CommandError is a failed git command together with what it printed on
+// stderr.
+type CommandError struct {
+	Command string
+	Stderr  string
+	Err     error
+}
+
+func (e *CommandError) Error() string {
+	return fmt.Sprintf("%s failed: %s", e.Command, e.Stderr)
+}
+
+func (e *CommandError) Unwrap() error {
+	return e.Err
+}
+
+// RunnerOptions configures how a Runner starts git. A zero timeout means no
+// limit. GitPath may be a name looked up in PATH or a path to the binary or
+// a wrapper script; it defaults to "git". Each of Options is passed as
+// "-c key=value" before every command, and Env is added to git's environment
+// as KEY=VALUE pairs.
+type RunnerOptions struct {
+	ReadTimeout  time.Duration
+	WriteTimeout time.Duration
+	GitPath      string
+	Options      []string
+	Env          []string
+}
+
 type Runner struct {
-	Timeout time.Duration
-	GitPath string
+	ReadTimeout  time.Duration
+	WriteTimeout time.Duration
+	GitPath      string
+	Options      []string
+	Env          []string
+	// missing is the configured git path when it could not be found.
+	missing string
 }
 
-func NewRunner(timeout time.Duration) Runner {
-	if timeout <= 0 {
-		timeout = 4 * time.Second
+func NewRunner(opts RunnerOptions) Runner {
+	want := strings.TrimSpace(opts.GitPath)
+	if want == "" {
+		want = "git"
+	}
+	r := Runner{
+		ReadTimeout:  opts.ReadTimeout,
+		WriteTimeout: opts.WriteTimeout,
+		Options:      opts.Options,
+		Env:          opts.Env,
+	}
+	gitPath, err := exec.LookPath(want)
+	if err != nil {
+		r.missing = want
+		return r
 	}
-	gitPath, _ := exec.LookPath("git")
-	return Runner{Timeout: timeout, GitPath: gitPath}
+	r.GitPath = gitPath
+	return r
 }
 
+// Run runs a git command that may change the repository, limited by
+// WriteTimeout.
 func (r Runner) Run(args ...string) (string, string, error) {
+	return r.run(nil, nil, args...)
+}
+
+// RunRead runs a git command that only reads the repository, limited by
+// ReadTimeout.
+func (r Runner) RunRead(args ...string) (string, string, error) {
+	return r.exec(context.Background(), r.ReadTimeout, nil, nil, nil, args...)
+}
+
+// RunWithInput runs git with input on stdin, e.g. a patch for "git apply -".
+func (r Runner) RunWithInput(input string, args ...string) (string, string, error) {
+	return r.run(nil, strings.NewReader(input), args...)
+}
+
+// RunWithEnv runs git with extra environment variables, e.g. an editor
+// override for commands that would otherwise open one.
+func (r Runner) RunWithEnv(env []string, args ...string) (string, string, error) {
+	return r.run(env, nil, args...)
+}
+
+// ErrCancelled is wrapped by the error of a streamed command that was
+// cancelled through its Stream context.
+var ErrCancelled = errors.New("cancelled")
+
+// Stream receives what a command prints while RunStreaming runs it. Line gets
+// every complete line and Progress every --progress report. Cancelling
+// Context stops the command.
+type Stream struct {
+	Context  context.Context
+	Line     func(string)
+	Progress func(Progress)
+}
+
+// RunStreaming runs git while passing its output to st as it arrives. Unlike
+// Run, it only stops after timeout when that is above zero, so it suits
+// commands that run hooks or talk to a remote.
+func (r Runner) RunStreaming(st Stream, timeout time.Duration, args ...string) (string, string, error) {
+	return r.RunStreamingWithEnv(st, timeout, nil, args...)
+}
+
+// RunStreamingWithEnv is RunStreaming with extra environment variables.
+func (r Runner) RunStreamingWithEnv(st Stream, timeout time.Duration, env []string, args ...string) (string, string, error) {
+	ctx := st.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	var lw *lineWriter
+	if st.Line != nil || st.Progress != nil {
+		lw = &lineWriter{onLine: st.Line, onProgress: st.Progress}
+	}
+	return r.exec(ctx, timeout, lw, env, nil, args...)
+}
+
+func (r Runner) run(env []string, stdin io.Reader, args ...string) (string, string, error) {
+	return r.exec(context.Background(), r.WriteTimeout, nil, env, stdin, args...)
+}
+
+func (r Runner) exec(ctx context.Context, timeout time.Duration, lw *lineWriter, env []string, stdin io.Reader, args ...string) (string, string, error) {
 	cmdStr := "git " + strings.Join(args, " ")
 	if strings.TrimSpace(r.GitPath) == "" {
-		return "", cmdStr, fmt.Errorf("git executable not found in PATH")
+		return "", cmdStr, r.notFound()
+	}
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), r.Timeout)
-	defer cancel()
-
-	cmd := exec.CommandContext(ctx, r.GitPath, args...)
+	// Options go before the subcommand but stay out of cmdStr, which is what
+	// the command log shows.
+	full := make([]string, 0, 2*len(r.Options)+len(args))
+	for _, opt := range r.Options {
+		full = append(full, "-c", opt)
+	}
+	full = append(full, args...)
+	cmd := exec.CommandContext(ctx, r.GitPath, full...)
+	cmd.Stdin = stdin
+	if len(r.Env) > 0 || len(env) > 0 {
+		cmd.Env = append(append(os.Environ(), r.Env...), env...)
+	}
 	var out bytes.Buffer
 	var errBuf bytes.Buffer
 	cmd.Stdout = &out
 	cmd.Stderr = &errBuf
+	// Children such as hooks may keep the pipes open after git is killed;
+	// stop waiting for them shortly after.
+	cmd.WaitDelay = time.Second
+	if lw != nil {
+		defer lw.Flush()
+		cmd.Stdout = io.MultiWriter(&out, lw)
+		cmd.Stderr = io.MultiWriter(&errBuf, lw)
+	}
 
 	err := cmd.Run()
 	stdout := strings.TrimRight(out.String(), "\r\n")
@@ -43,14 +174,85 @@ func (r Runner) Run(args ...string) (string, string, error) {
 	if err == nil {
 		return stdout, cmdStr, nil
 	}
-	if ctx.Err() == context.DeadlineExceeded {
-		return stdout, cmdStr, fmt.Errorf("%s timeout after %s", cmdStr, r.Timeout)
+	switch ctx.Err() {
+	case context.DeadlineExceeded:
+		return stdout, cmdStr, fmt.Errorf("%s timeout after %s", cmdStr, timeout)
+	case context.Canceled:
+		return stdout, cmdStr, fmt.Errorf("%s %w", cmdStr, ErrCancelled)
 	}
 	if stderr != "" {
-		return stdout, cmdStr, fmt.Errorf("%s failed: %s", cmdStr, stderr)
+		return stdout, cmdStr, &CommandError{Command: cmdStr, Stderr: stderr, Err: err}
 	}
 	if ee, ok := err.(*exec.Error); ok && ee.Err == exec.ErrNotFound {
-		return stdout, cmdStr, fmt.Errorf("git executable not found in PATH")
+		return stdout, cmdStr, r.notFound()
 	}
 	return stdout, cmdStr, fmt.Errorf("%s failed: %w", cmdStr, err)
 }
+
+func (r Runner) notFound() error {
+	if r.missing != "" && r.missing != "git" {
+		return fmt.Errorf("git executable %q not found", r.missing)
+	}
+	return fmt.Errorf("git executable not found in PATH")
+}
+
+// lineWriter splits what a command prints into lines. Stdout and stderr share
+// one lineWriter so their lines keep the order they were printed in. Text
+// ended by a lone carriage return is a progress report that the next one
+// redraws; it is passed to onProgress but not kept as a line.
+type lineWriter struct {
+	mu         sync.Mutex
+	onLine     func(string)
+	onProgress func(Progress)
+	pending    []byte
+}
+
+func (w *lineWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.pending = append(w.pending, p...)
+	for {
+		i := bytes.IndexAny(w.pending, "\r\n")
+		if i < 0 {
+			return len(p), nil
+		}
+		if w.pending[i] == '\n' {
+			w.emit(w.pending[:i], true)
+			w.pending = w.pending[i+1:]
+			continue
+		}
+		if i+1 == len(w.pending) {
+			// Wait for what follows: "\r\n" still ends a line.
+			return len(p), nil
+		}
+		if w.pending[i+1] == '\n' {
+			w.emit(w.pending[:i], true)
+			w.pending = w.pending[i+2:]
+			continue
+		}
+		w.emit(w.pending[:i], false)
+		w.pending = w.pending[i+1:]
+	}
+}
+
+// Flush passes on a last line that did not end with a newline.
+func (w *lineWriter) Flush() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if line := bytes.TrimRight(w.pending, "\r"); len(line) > 0 {
+		w.emit(line, true)
+	}
+	w.pending = nil
+}
+
+func (w *lineWriter) emit(text []byte, complete bool) {
+	// Remote lines are padded with spaces, and rebase lines start with an
+	// erase-line sequence, to clear what was drawn before.
+	line := strings.TrimRight(strings.ReplaceAll(string(text), "\x1b[K", ""), " ")
+	if p, ok := parseProgress(line); ok && w.onProgress != nil {
+		w.onProgress(p)
+	}
+	if complete && w.onLine != nil {
+		w.onLine(line)
+	}
+}
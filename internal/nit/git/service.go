@@ -1,71 +1,93 @@
 package git
 
 import (
+	"fmt"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 )
 
 type Service struct {
-	runner Runner
+	runner          Runner
+	graphSignatures bool
+	stream          Stream
+	fetchTimeout    time.Duration
+	pullTimeout     time.Duration
+	pushTimeout     time.Duration
 }
 
 func NewService(r Runner) Service {
 	return Service{runner: r}
 }
 
-func (s Service) LoadGraph() ([]string, error) {
-	out, _, err := s.runner.Run("--no-optional-locks", "log", "--graph", "--decorate", "--oneline", "--all")
-	if err != nil {
-		return []string{"Not a git repo or no commits yet."}, err
-	}
-	if strings.TrimSpace(out) == "" {
-		return []string{"No commits to display."}, nil
+// WithGraphSignatures makes LoadGraph verify commit signatures.
+func (s Service) WithGraphSignatures(on bool) Service {
+	s.graphSignatures = on
+	return s
+}
+
+func (s Service) LoadGraph() ([]Commit, error) {
+	format := graphLogFormat
+	if s.graphSignatures {
+		format = graphLogFormatSignatures
 	}
-	lines := strings.Split(out, "\n")
-	for i := range lines {
-		lines[i] = prettifyGraphLine(lines[i])
+	// The refs that keep discard snapshots alive are not history.
+	out, _, err := s.runner.RunRead("--no-optional-locks", "log", "--exclude="+snapshotRef("*"), "--all", "--topo-order", format)
+	if err != nil {
+		return nil, err
 	}
-	return lines, nil
+	return parseGraphLog(out), nil
 }
 
-func (s Service) LoadBranches() ([]string, error) {
-	out, _, err := s.runner.Run(
+// LoadBranches lists local branches, remote-tracking branches and tags, in
+// that order.
+func (s Service) LoadBranches() ([]Branch, error) {
+	out, _, err := s.runner.RunRead(
 		"--no-optional-locks",
 		"for-each-ref",
-		"--format=%(HEAD) %(refname:short)",
+		"--format=%(HEAD)%00%(refname)%00%(refname:short)%00%(upstream:short)%00%(upstream:track)",
 		"refs/heads",
+		"refs/remotes",
+		"refs/tags",
 	)
 	if err != nil {
-		return []string{"Not a git repo."}, err
-	}
-	if strings.TrimSpace(out) == "" {
-		return []string{"No local branches."}, nil
+		return nil, err
 	}
-	raw := strings.Split(out, "\n")
-	lines := make([]string, 0, len(raw))
-	for _, line := range raw {
-		line = strings.TrimRight(line, " \t")
-		if line == "" {
-			continue
-		}
-		if strings.HasPrefix(line, "* ") {
-			lines = append(lines, "● "+strings.TrimSpace(strings.TrimPrefix(line, "* ")))
+	return parseBranchRefs(out), nil
+}
+
+func parseBranchRefs(out string) []Branch {
+	var local, remote, tags []Branch
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Split(line, "\x00")
+		if len(fields) != 5 {
 			continue
 		}
-		if strings.HasPrefix(line, "  ") {
-			lines = append(lines, "  "+strings.TrimSpace(line[2:]))
-			continue
+		refname := fields[1]
+		b := Branch{Name: fields[2], Head: fields[0] == "*", Upstream: fields[3]}
+		b.Ahead, b.Behind, b.UpstreamGone = parseUpstreamTrack(fields[4])
+		switch {
+		case strings.HasPrefix(refname, "refs/heads/"):
+			b.Kind = BranchLocal
+			local = append(local, b)
+		case strings.HasPrefix(refname, "refs/remotes/"):
+			// refs/remotes/<remote>/HEAD only points at another remote branch.
+			if strings.HasSuffix(refname, "/HEAD") {
+				continue
+			}
+			b.Kind = BranchRemote
+			remote = append(remote, b)
+		case strings.HasPrefix(refname, "refs/tags/"):
+			b.Kind = BranchTag
+			tags = append(tags, b)
 		}
-		lines = append(lines, "  "+strings.TrimSpace(line))
 	}
-	if len(lines) == 0 {
-		return []string{"No local branches."}, nil
-	}
-	return lines, nil
+	return append(append(local, remote...), tags...)
 }
 
 func (s Service) LoadChanges() ([]ChangeEntry, error) {
-	out, _, err := s.runner.Run("--no-optional-locks", "status", "--porcelain", "--untracked-files=all")
+	out, _, err := s.runner.RunRead("--no-optional-locks", "status", "--porcelain", "--untracked-files=all")
 	if err != nil {
 		return nil, err
 	}
@@ -82,19 +104,74 @@ func (s Service) LoadChanges() ([]ChangeEntry, error) {
 	return entries, nil
 }
 
-func (s Service) LoadRepoSummary() (string, string, error) {
-	root, _, err := s.runner.Run("--no-optional-locks", "rev-parse", "--show-toplevel")
+func (s Service) LoadRepoSummary() (RepoSummary, error) {
+	root, gitDir, err := s.repoDirs()
+	if err != nil {
+		return RepoSummary{}, err
+	}
+	summary := RepoSummary{Repo: filepath.Base(root)}
+	summary.State, summary.Step, summary.Steps = repoState(gitDir)
+	branch, _, err := s.runner.RunRead("--no-optional-locks", "branch", "--show-current")
 	if err != nil {
-		return "", "", err
+		return summary, err
+	}
+	summary.Branch = strings.TrimSpace(branch)
+	if summary.Branch == "" {
+		summary.Branch = "(detached)"
+		return summary, nil
 	}
-	repo := filepath.Base(strings.TrimSpace(root))
-	branch, _, err := s.runner.Run("--no-optional-locks", "branch", "--show-current")
+	upstream, _, err := s.runner.RunRead("--no-optional-locks", "rev-parse", "--abbrev-ref", "--symbolic-full-name", "@{u}")
 	if err != nil {
-		return repo, "", err
+		// No upstream configured.
+		return summary, nil
+	}
+	summary.Upstream = strings.TrimSpace(upstream)
+	summary.Ahead, summary.Behind, _ = s.aheadBehind()
+	return summary, nil
+}
+
+// aheadBehind counts the commits HEAD has that its upstream lacks, and the
+// other way around.
+func (s Service) aheadBehind() (ahead, behind int, err error) {
+	out, _, err := s.runner.RunRead("--no-optional-locks", "rev-list", "--left-right", "--count", "HEAD...@{u}")
+	if err != nil {
+		return 0, 0, err
+	}
+	fields := strings.Fields(out)
+	if len(fields) != 2 {
+		return 0, 0, fmt.Errorf("unexpected rev-list output %q", strings.TrimSpace(out))
+	}
+	if ahead, err = strconv.Atoi(fields[0]); err != nil {
+		return 0, 0, err
+	}
+	if behind, err = strconv.Atoi(fields[1]); err != nil {
+		return 0, 0, err
 	}
-	br := strings.TrimSpace(branch)
-	if br == "" {
-		br = "(detached)"
+	return ahead, behind, nil
+}
+
+// parseUpstreamTrack reads %(upstream:track), e.g. "[ahead 2, behind 5]" or
+// "[gone]".
+func parseUpstreamTrack(track string) (ahead, behind int, gone bool) {
+	track = strings.Trim(strings.TrimSpace(track), "[]")
+	if track == "gone" {
+		return 0, 0, true
+	}
+	for _, part := range strings.Split(track, ",") {
+		word, count, ok := strings.Cut(strings.TrimSpace(part), " ")
+		if !ok {
+			continue
+		}
+		n, err := strconv.Atoi(count)
+		if err != nil {
+			continue
+		}
+		switch word {
+		case "ahead":
+			ahead = n
+		case "behind":
+			behind = n
+		}
 	}
-	return repo, br, nil
+	return ahead, behind, false
 }
@@ -0,0 +1,167 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// commitDetailFormat is NUL separated; the message comes last so it may
+// contain anything but NUL.
+const commitDetailFormat = "--format=%H%x00%h%x00%P%x00%D%x00%an%x00%ae%x00%aI%x00%cn%x00%ce%x00%cI%x00%G?%x00%GS%x00%GK%x00%B"
+
+func (s Service) LoadCommitDetail(hash string) (CommitDetail, error) {
+	d, err := s.LoadCommitHeader(hash)
+	if err != nil {
+		return CommitDetail{Hash: hash}, err
+	}
+	d.Files, err = s.loadCommitFiles(d)
+	return d, err
+}
+
+// LoadCommitHeader loads a commit's metadata and message without its list of
+// changed files.
+func (s Service) LoadCommitHeader(rev string) (CommitDetail, error) {
+	out, _, err := s.runner.RunRead("--no-optional-locks", "show", "-s", "--no-color", commitDetailFormat, rev)
+	if err != nil {
+		return CommitDetail{}, err
+	}
+	return parseCommitDetail(out)
+}
+
+// HeadPushedTo returns the upstream of the current branch when HEAD is
+// already on it, so that rewriting HEAD would rewrite pushed history, and ""
+// otherwise, including when there is no upstream.
+func (s Service) HeadPushedTo() (string, error) {
+	upstream, _, err := s.runner.RunRead("--no-optional-locks", "rev-parse", "--abbrev-ref", "--symbolic-full-name", "@{u}")
+	if err != nil {
+		// No upstream configured.
+		return "", nil
+	}
+	_, _, err = s.runner.RunRead("--no-optional-locks", "merge-base", "--is-ancestor", "HEAD", "@{u}")
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(upstream), nil
+}
+
+// LoadCommitTemplate reads the file commit.template points to, without its
+// comment lines. Leading blank lines are kept, since templates usually leave
+// the subject line empty. It returns an empty string when no template is
+// configured.
+func (s Service) LoadCommitTemplate() (string, error) {
+	path, err := s.configValue("--path", "commit.template")
+	if err != nil || path == "" {
+		return "", err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("cannot read commit.template: %w", err)
+	}
+	comment, err := s.commentChar()
+	if err != nil {
+		return "", err
+	}
+	return stripCommentLines(string(data), comment), nil
+}
+
+// configValue reads a git config value, returning "" when it is unset. A type
+// flag such as --path may be given in typ.
+func (s Service) configValue(typ, key string) (string, error) {
+	args := []string{"config"}
+	if typ != "" {
+		args = append(args, typ)
+	}
+	out, _, err := s.runner.RunRead(append(args, "--get", key)...)
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+		return "", nil
+	}
+	return strings.TrimSpace(out), err
+}
+
+func parseCommitDetail(raw string) (CommitDetail, error) {
+	f := strings.SplitN(raw, "\x00", 14)
+	if len(f) < 14 {
+		return CommitDetail{}, fmt.Errorf("unexpected git show output")
+	}
+	d := CommitDetail{
+		Hash:           f[0],
+		ShortHash:      f[1],
+		Parents:        strings.Fields(f[2]),
+		Refs:           f[3],
+		AuthorName:     f[4],
+		AuthorEmail:    f[5],
+		CommitterName:  f[7],
+		CommitterEmail: f[8],
+		Signature:      parseSignatureStatus(f[10]),
+		Signer:         f[11],
+		SigningKey:     f[12],
+		Message:        strings.TrimRight(f[13], "\n"),
+	}
+	d.AuthorDate, _ = time.Parse(time.RFC3339, f[6])
+	d.CommitDate, _ = time.Parse(time.RFC3339, f[9])
+	return d, nil
+}
+
+// loadCommitFiles lists the paths a commit changed. Merges are compared with
+// their first parent, which is what the per-file diff shows as well.
+func (s Service) loadCommitFiles(d CommitDetail) ([]CommitFile, error) {
+	args := []string{"--no-optional-locks", "diff-tree", "-r", "-M", "--name-status", "-z", "--no-commit-id"}
+	if len(d.Parents) == 0 {
+		args = append(args, "--root", d.Hash)
+	} else {
+		args = append(args, d.Parents[0], d.Hash)
+	}
+	out, _, err := s.runner.RunRead(args...)
+	if err != nil {
+		return nil, err
+	}
+	return parseNameStatusZ(out), nil
+}
+
+func parseNameStatusZ(raw string) []CommitFile {
+	fields := strings.Split(strings.TrimRight(raw, "\x00"), "\x00")
+	files := []CommitFile{}
+	for i := 0; i < len(fields); i++ {
+		status := fields[i]
+		if status == "" || i+1 >= len(fields) {
+			continue
+		}
+		if (status[0] == 'R' || status[0] == 'C') && i+2 < len(fields) {
+			files = append(files, CommitFile{Status: status[:1], OldPath: fields[i+1], Path: fields[i+2]})
+			i += 2
+			continue
+		}
+		files = append(files, CommitFile{Status: status[:1], Path: fields[i+1]})
+		i++
+	}
+	return files
+}
+
+// LoadCommitFileDiff shows how one file changed in a commit, relative to the
+// commit's first parent.
+func (s Service) LoadCommitFileDiff(d CommitDetail, file CommitFile) (FileDiff, error) {
+	args := []string{"--no-optional-locks"}
+	if len(d.Parents) == 0 {
+		args = append(args, "show", "--format=", "--no-color", "--no-ext-diff", d.Hash)
+	} else {
+		args = append(args, "diff", "--no-color", "--no-ext-diff", "-M", d.Parents[0], d.Hash)
+	}
+	args = append(args, "--", file.Path)
+	if file.OldPath != "" {
+		args = append(args, file.OldPath)
+	}
+	out, _, err := s.runner.RunRead(args...)
+	if err != nil {
+		return FileDiff{Path: file.Path}, err
+	}
+	return ParseDiff(file.Path, false, out), nil
+}
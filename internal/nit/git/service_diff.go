@@ -0,0 +1,49 @@
+package git
+
+import (
+	"errors"
+	"os/exec"
+	"strings"
+)
+
+func (s Service) LoadDiff(path string, staged bool) (FileDiff, error) {
+	args := []string{"--no-optional-locks", "diff", "--no-color", "--no-ext-diff"}
+	if staged {
+		args = append(args, "--cached")
+	}
+	args = append(args, "--", path)
+	out, _, err := s.runner.RunRead(args...)
+	if err != nil {
+		return FileDiff{Path: path, Staged: staged}, err
+	}
+	if strings.TrimSpace(out) == "" && !staged {
+		untracked, uErr := s.isUntracked(path)
+		if uErr != nil {
+			return FileDiff{Path: path, Staged: staged}, uErr
+		}
+		if untracked {
+			return s.loadUntrackedDiff(path)
+		}
+	}
+	return ParseDiff(path, staged, out), nil
+}
+
+func (s Service) isUntracked(path string) (bool, error) {
+	out, _, err := s.runner.RunRead("--no-optional-locks", "ls-files", "--others", "--exclude-standard", "--", path)
+	if err != nil {
+		return false, err
+	}
+	return strings.TrimSpace(out) != "", nil
+}
+
+// loadUntrackedDiff shows an untracked file as a full addition. git diff
+// --no-index exits with status 1 whenever the inputs differ, which is the
+// expected outcome here.
+func (s Service) loadUntrackedDiff(path string) (FileDiff, error) {
+	out, _, err := s.runner.RunRead("--no-optional-locks", "diff", "--no-color", "--no-ext-diff", "--no-index", "--", "/dev/null", path)
+	var exitErr *exec.ExitError
+	if err != nil && !(errors.As(err, &exitErr) && exitErr.ExitCode() == 1) {
+		return FileDiff{Path: path}, err
+	}
+	return ParseDiff(path, false, out), nil
+}
@@ -0,0 +1,90 @@
+package git
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// commitMessageFileName is the name git gives the file it edits commit
+// messages in; editors recognize it and highlight it as a commit message.
+const commitMessageFileName = "COMMIT_EDITMSG"
+
+// Editor is the editor git itself would run, from $GIT_EDITOR, core.editor,
+// $VISUAL or $EDITOR, in that order, falling back to vi. It is a shell
+// command that may carry arguments, such as "code --wait".
+func (s Service) Editor() (string, error) {
+	out, _, err := s.runner.RunRead("--no-optional-locks", "var", "GIT_EDITOR")
+	if editor := strings.TrimSpace(out); err == nil && editor != "" {
+		return editor, nil
+	}
+	return "vi", nil
+}
+
+// commentChar is the character starting the comment lines of commit messages.
+func (s Service) commentChar() (string, error) {
+	comment, err := s.configValue("", "core.commentChar")
+	if err != nil {
+		return "", err
+	}
+	if comment == "" || comment == "auto" {
+		comment = "#"
+	}
+	return comment, nil
+}
+
+// WriteCommitMessageFile writes message into a COMMIT_EDITMSG file of its own
+// temporary directory, followed by comment lines explaining how it is used,
+// and returns its path. ReadCommitMessageFile reads it back.
+func (s Service) WriteCommitMessageFile(message string) (string, error) {
+	comment, err := s.commentChar()
+	if err != nil {
+		return "", err
+	}
+	dir, err := os.MkdirTemp("", "nit-commit-")
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, commitMessageFileName)
+	text := strings.TrimRight(message, " \t\n") + "\n\n" +
+		comment + " Write the commit message, then save and close the editor to\n" +
+		comment + " return to nit. Lines starting with '" + comment + "' are ignored; an\n" +
+		comment + " empty message leaves the message in nit as it was.\n"
+	if err := os.WriteFile(path, []byte(text), 0o600); err != nil {
+		os.RemoveAll(dir)
+		return "", err
+	}
+	return path, nil
+}
+
+// ReadCommitMessageFile reads back a file written by WriteCommitMessageFile,
+// without its comment lines, and removes it.
+func (s Service) ReadCommitMessageFile(path string) (string, error) {
+	if filepath.Base(path) != commitMessageFileName {
+		return "", errors.New("not a commit message file: " + path)
+	}
+	defer os.RemoveAll(filepath.Dir(path))
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	comment, err := s.commentChar()
+	if err != nil {
+		return "", err
+	}
+	return strings.Trim(stripCommentLines(string(data), comment), "\n"), nil
+}
+
+// stripCommentLines drops the lines starting with comment and the trailing
+// blanks of the others.
+func stripCommentLines(text, comment string) string {
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	kept := lines[:0]
+	for _, line := range lines {
+		if !strings.HasPrefix(line, comment) {
+			kept = append(kept, strings.TrimRight(line, " \t"))
+		}
+	}
+	return strings.TrimRight(strings.Join(kept, "\n"), "\n")
+}
@@ -0,0 +1,37 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Hooks that git runs for the commands nit streams.
+var (
+	CommitHooks = []string{"pre-commit", "prepare-commit-msg", "commit-msg", "post-commit"}
+	PushHooks   = []string{"pre-push"}
+	MergeHooks  = []string{"pre-merge-commit", "commit-msg", "post-merge"}
+	// SequenceHooks run for every commit a cherry-pick or revert makes.
+	SequenceHooks = []string{"prepare-commit-msg", "post-commit"}
+	// RebaseHooks run while a rebase starts, picks commits and finishes.
+	RebaseHooks = []string{"pre-rebase", "prepare-commit-msg", "post-commit", "post-rewrite"}
+)
+
+// InstalledHooks returns which of the named hooks are installed, honouring
+// core.hooksPath. Like git, it ignores hooks that are not executable.
+func (s Service) InstalledHooks(names ...string) []string {
+	dir, _, err := s.runner.RunRead("rev-parse", "--git-path", "hooks")
+	if err != nil {
+		return nil
+	}
+	dir = strings.TrimSpace(dir)
+	var installed []string
+	for _, name := range names {
+		info, err := os.Stat(filepath.Join(dir, name))
+		if err != nil || info.IsDir() || info.Mode()&0o111 == 0 {
+			continue
+		}
+		installed = append(installed, name)
+	}
+	return installed
+}
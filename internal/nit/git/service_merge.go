@@ -0,0 +1,120 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// MergeConflictError is a merge, pull, cherry-pick or revert that stopped
+// because files conflict. Files are the paths left unmerged and State is the
+// operation left in progress.
+type MergeConflictError struct {
+	Command string
+	Files   []string
+	State   RepoState
+}
+
+func (e *MergeConflictError) Error() string {
+	return fmt.Sprintf("%s stopped with %d conflicted file(s); resolve them and continue the %s", e.Command, len(e.Files), e.State)
+}
+
+// Merge merges ref into the current branch. The default mode fast-forwards
+// when it can; MergeFastForward refuses anything else and MergeNoFF always
+// creates a merge commit. With noVerify the merge hooks are skipped.
+func (s Service) Merge(ref string, mode MergeMode, noVerify bool) (string, error) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return "", errors.New("no branch to merge")
+	}
+	args := []string{"merge", "--no-edit"}
+	if mode != MergeDefault {
+		args = append(args, "--"+string(mode))
+	}
+	if noVerify {
+		args = append(args, "--no-verify")
+	}
+	_, cmd, err := s.runHooked(append(args, ref)...)
+	return cmd, s.conflictError(cmd, err, RepoMerging)
+}
+
+// ContinueMerge commits a merge whose conflicts are resolved, keeping the
+// message git prepared. Like Merge it has no timeout, since the commit runs
+// hooks.
+func (s Service) ContinueMerge() (string, error) {
+	_, cmd, err := s.runHookedNoEditor("merge", "--continue")
+	return cmd, err
+}
+
+func (s Service) AbortMerge() (string, error) {
+	_, cmd, err := s.runner.Run("merge", "--abort")
+	return cmd, err
+}
+
+// conflictHeads names the ref git writes while state waits for conflicts to
+// be resolved.
+var conflictHeads = map[RepoState]string{
+	RepoMerging:       "MERGE_HEAD",
+	RepoCherryPicking: "CHERRY_PICK_HEAD",
+	RepoReverting:     "REVERT_HEAD",
+}
+
+// conflictError turns the failure of a command that merges into a
+// MergeConflictError when it left state in progress with unmerged paths.
+func (s Service) conflictError(cmd string, err error, state RepoState) error {
+	if err == nil || errors.Is(err, ErrCancelled) {
+		return err
+	}
+	// A pull that rebases stops with conflicts too, but not in a merge.
+	if _, _, headErr := s.runner.RunRead("--no-optional-locks", "rev-parse", "-q", "--verify", conflictHeads[state]); headErr != nil {
+		return err
+	}
+	out, _, listErr := s.runner.RunRead("--no-optional-locks", "diff", "--name-only", "--diff-filter=U")
+	if listErr != nil || strings.TrimSpace(out) == "" {
+		return err
+	}
+	return &MergeConflictError{Command: cmd, Files: strings.Split(out, "\n"), State: state}
+}
+
+// LoadConflict reads a conflicted file from the working tree and finds its
+// conflict blocks.
+func (s Service) LoadConflict(path string) (ConflictFile, error) {
+	full, err := s.worktreePath(path)
+	if err != nil {
+		return ConflictFile{Path: path}, err
+	}
+	data, err := os.ReadFile(full)
+	if err != nil {
+		return ConflictFile{Path: path}, err
+	}
+	return ParseConflicts(path, string(data)), nil
+}
+
+// ResolveConflict writes the resolved content of a conflicted file and
+// stages it, which marks it resolved.
+func (s Service) ResolveConflict(path, content string) (string, error) {
+	full, err := s.worktreePath(path)
+	if err != nil {
+		return "", err
+	}
+	info, err := os.Stat(full)
+	if err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(full, []byte(content), info.Mode().Perm()); err != nil {
+		return "", err
+	}
+	return s.StagePath(path)
+}
+
+// worktreePath turns a path from git status, which is relative to the top of
+// the working tree, into one that can be opened.
+func (s Service) worktreePath(path string) (string, error) {
+	root, _, err := s.runner.RunRead("--no-optional-locks", "rev-parse", "--show-toplevel")
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(strings.TrimSpace(root), path), nil
+}
@@ -0,0 +1,116 @@
+package git
+
+import (
+	"errors"
+	"os"
+	"strings"
+)
+
+// nonInteractiveEditor keeps git from opening an editor nit cannot show, e.g.
+// for the combined message of a squash. The default message is kept.
+const nonInteractiveEditor = "GIT_EDITOR=true"
+
+// LoadRebaseTodo lists the commits an interactive rebase onto base would
+// replay, oldest first, all set to pick, with their full messages. Merge
+// commits are left out, as "git rebase -i" does without --rebase-merges.
+func (s Service) LoadRebaseTodo(base string) ([]RebaseTodoItem, error) {
+	base = strings.TrimSpace(base)
+	if base == "" {
+		return nil, errors.New("no base commit")
+	}
+	out, _, err := s.runner.RunRead("--no-optional-locks", "log", "--no-merges", "--topo-order", "--reverse", "--format=%H%x1f%h%x1f%s%x1f%B%x1e", base+"..HEAD")
+	if err != nil {
+		return nil, err
+	}
+	return parseRebaseTodo(out), nil
+}
+
+// parseRebaseTodo parses the records LoadRebaseTodo asks git log for: hash,
+// short hash, subject and message separated by \x1f, each ending in \x1e.
+func parseRebaseTodo(out string) []RebaseTodoItem {
+	var items []RebaseTodoItem
+	for _, record := range strings.Split(out, "\x1e") {
+		fields := strings.SplitN(strings.TrimLeft(record, "\n"), "\x1f", 4)
+		if len(fields) != 4 {
+			continue
+		}
+		items = append(items, RebaseTodoItem{
+			Action:      RebasePick,
+			Hash:        fields[0],
+			ShortHash:   fields[1],
+			Subject:     fields[2],
+			FullMessage: strings.TrimRight(fields[3], "\n"),
+		})
+	}
+	return items
+}
+
+// RebaseTodoScript renders items as a git-rebase-todo file. A reword with a
+// new message becomes a pick followed by an exec that amends the message, so
+// no editor has to open mid-rebase.
+func RebaseTodoScript(items []RebaseTodoItem) string {
+	var b strings.Builder
+	for _, it := range items {
+		action := it.Action
+		if action == RebaseReword && strings.TrimSpace(it.Message) != "" {
+			action = RebasePick
+		}
+		b.WriteString(string(action) + " " + it.Hash + " " + it.Subject + "\n")
+		if action != it.Action {
+			b.WriteString(rewordExec(it.Message) + "\n")
+		}
+	}
+	return b.String()
+}
+
+// rewordExec is the exec line replacing the message of the commit just
+// picked with message. The todo list takes one command per line, so the
+// lines of the message are printed back together by printf.
+func rewordExec(message string) string {
+	lines := strings.Split(strings.ReplaceAll(message, "\r\n", "\n"), "\n")
+	quoted := make([]string, len(lines))
+	for i, line := range lines {
+		quoted[i] = shellQuote(line)
+	}
+	return "exec printf '%s\\n' " + strings.Join(quoted, " ") + " | git commit --amend --only --allow-empty --quiet -F -"
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// StartInteractiveRebase rebases the commits after base using todo, a
+// git-rebase-todo script, which is handed to git through GIT_SEQUENCE_EDITOR.
+// It has no timeout, since the rebase runs hooks and the exec lines of
+// rewords, and stopping it would leave the rebase halfway.
+func (s Service) StartInteractiveRebase(base, todo string) (string, error) {
+	base = strings.TrimSpace(base)
+	if base == "" {
+		return "", errors.New("no base commit")
+	}
+	f, err := os.CreateTemp("", "nit-rebase-todo-*")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.WriteString(todo); err != nil {
+		f.Close()
+		return "", err
+	}
+	if err := f.Close(); err != nil {
+		return "", err
+	}
+	env := []string{"GIT_SEQUENCE_EDITOR=cp " + shellQuote(f.Name()), nonInteractiveEditor}
+	_, cmd, err := s.runner.RunStreamingWithEnv(s.stream, 0, env, "rebase", "-i", base)
+	return cmd, err
+}
+
+func (s Service) ContinueRebase() (string, error) {
+	_, cmd, err := s.runHookedNoEditor("rebase", "--continue")
+	return cmd, err
+}
+
+func (s Service) SkipRebase() (string, error) {
+	_, cmd, err := s.runHooked("rebase", "--skip")
+	return cmd, err
+}
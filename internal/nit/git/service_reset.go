@@ -0,0 +1,55 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Reset moves the current branch to hash. Untracked files are never touched.
+// A hard reset first saves a snapshot of the changes it throws away, and
+// does nothing when that fails.
+func (s Service) Reset(hash string, mode ResetMode) (string, error) {
+	hash = strings.TrimSpace(hash)
+	if hash == "" {
+		return "", errors.New("no commit to reset to")
+	}
+	switch mode {
+	case ResetSoft, ResetMixed, ResetHard:
+	default:
+		return "", errors.New("unknown reset mode " + strconv.Quote(string(mode)))
+	}
+	var cmdLog string
+	if mode == ResetHard {
+		var err error
+		cmdLog, err = s.saveSnapshot("Reset --hard to "+hash[:min(7, len(hash))], "")
+		if err != nil {
+			return cmdLog, fmt.Errorf("nothing was discarded, saving a snapshot failed: %w", err)
+		}
+	}
+	_, cmd, err := s.runner.Run("reset", "--"+string(mode), hash)
+	if cmdLog != "" {
+		cmd = cmdLog + " && " + cmd
+	}
+	return cmd, err
+}
+
+// LoadResetPreview lists what "git reset --hard hash" would discard.
+func (s Service) LoadResetPreview(hash string) (ResetPreview, error) {
+	hash = strings.TrimSpace(hash)
+	if hash == "" {
+		return ResetPreview{}, errors.New("no commit to reset to")
+	}
+	out, _, err := s.runner.RunRead("--no-optional-locks", "diff", "--name-status", "-z", "-M", "HEAD")
+	if err != nil {
+		return ResetPreview{}, err
+	}
+	preview := ResetPreview{Files: parseNameStatusZ(out)}
+	count, _, err := s.runner.RunRead("--no-optional-locks", "rev-list", "--count", hash+"..HEAD")
+	if err != nil {
+		return preview, err
+	}
+	preview.Commits, err = strconv.Atoi(strings.TrimSpace(count))
+	return preview, err
+}
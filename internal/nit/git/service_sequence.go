@@ -0,0 +1,128 @@
+package git
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// repoState finds the operation in progress from the files git keeps in
+// gitDir while it waits for the user. A rebase is checked first, because a
+// conflicted pick during a rebase leaves CHERRY_PICK_HEAD behind as well.
+func repoState(gitDir string) (state RepoState, step, steps int) {
+	if gitDir == "" {
+		return RepoClean, 0, 0
+	}
+	exists := func(name string) bool {
+		_, err := os.Stat(filepath.Join(gitDir, name))
+		return err == nil
+	}
+	switch {
+	case exists("rebase-merge"):
+		return RepoRebasing, readCount(gitDir, "rebase-merge", "msgnum"), readCount(gitDir, "rebase-merge", "end")
+	case exists("rebase-apply"):
+		return RepoRebasing, readCount(gitDir, "rebase-apply", "next"), readCount(gitDir, "rebase-apply", "last")
+	case exists("MERGE_HEAD"):
+		return RepoMerging, 0, 0
+	case exists("CHERRY_PICK_HEAD"):
+		return RepoCherryPicking, 0, 0
+	case exists("REVERT_HEAD"):
+		return RepoReverting, 0, 0
+	case exists("BISECT_LOG"):
+		return RepoBisecting, 0, 0
+	}
+	return RepoClean, 0, 0
+}
+
+func readCount(parts ...string) int {
+	data, err := os.ReadFile(filepath.Join(parts...))
+	if err != nil {
+		return 0
+	}
+	n, _ := strconv.Atoi(strings.TrimSpace(string(data)))
+	return n
+}
+
+// CherryPick applies hashes on top of HEAD in the order given. With
+// recordOrigin each message gets a "(cherry picked from commit ...)" line.
+// Merge commits are picked against their first parent when mainline is set.
+// It has no timeout, so that a long sequence is not stopped halfway.
+// Conflicts are reported as a MergeConflictError.
+func (s Service) CherryPick(hashes []string, recordOrigin, mainline bool) (string, error) {
+	if len(hashes) == 0 {
+		return "", errors.New("no commit to cherry-pick")
+	}
+	args := []string{"cherry-pick"}
+	if recordOrigin {
+		args = append(args, "-x")
+	}
+	if mainline {
+		args = append(args, "-m", "1")
+	}
+	_, cmd, err := s.runHooked(append(args, hashes...)...)
+	return cmd, s.conflictError(cmd, err, RepoCherryPicking)
+}
+
+// Revert commits the inverse of hash with git's default message, reverting a
+// merge commit against its first parent when mainline is set. Conflicts are
+// reported as a MergeConflictError.
+func (s Service) Revert(hash string, mainline bool) (string, error) {
+	hash = strings.TrimSpace(hash)
+	if hash == "" {
+		return "", errors.New("no commit to revert")
+	}
+	args := []string{"revert", "--no-edit"}
+	if mainline {
+		args = append(args, "-m", "1")
+	}
+	_, cmd, err := s.runHooked(append(args, hash)...)
+	return cmd, s.conflictError(cmd, err, RepoReverting)
+}
+
+// ContinueCherryPick commits the resolved commit and picks the rest. A later
+// commit that conflicts is reported as a MergeConflictError.
+func (s Service) ContinueCherryPick() (string, error) {
+	_, cmd, err := s.runHookedNoEditor("cherry-pick", "--continue")
+	return cmd, s.conflictError(cmd, err, RepoCherryPicking)
+}
+
+func (s Service) SkipCherryPick() (string, error) {
+	_, cmd, err := s.runHooked("cherry-pick", "--skip")
+	return cmd, s.conflictError(cmd, err, RepoCherryPicking)
+}
+
+func (s Service) AbortCherryPick() (string, error) {
+	_, cmd, err := s.runner.Run("cherry-pick", "--abort")
+	return cmd, err
+}
+
+// ContinueRevert commits the resolved revert and reverts the rest. A later
+// commit that conflicts is reported as a MergeConflictError.
+func (s Service) ContinueRevert() (string, error) {
+	_, cmd, err := s.runHookedNoEditor("revert", "--continue")
+	return cmd, s.conflictError(cmd, err, RepoReverting)
+}
+
+func (s Service) SkipRevert() (string, error) {
+	_, cmd, err := s.runHooked("revert", "--skip")
+	return cmd, s.conflictError(cmd, err, RepoReverting)
+}
+
+func (s Service) AbortRevert() (string, error) {
+	_, cmd, err := s.runner.Run("revert", "--abort")
+	return cmd, err
+}
+
+// SkipBisect leaves the current commit untested and checks out another one.
+func (s Service) SkipBisect() (string, error) {
+	_, cmd, err := s.runner.Run("bisect", "skip")
+	return cmd, err
+}
+
+// ResetBisect ends the bisect session and returns to the original branch.
+func (s Service) ResetBisect() (string, error) {
+	_, cmd, err := s.runner.Run("bisect", "reset")
+	return cmd, err
+}
@@ -0,0 +1,67 @@
+package git
+
+import (
+	"errors"
+	"strings"
+)
+
+// SigningConfig is how git signs commits by default.
+type SigningConfig struct {
+	Sign   bool
+	Format string
+}
+
+// SigningError is a commit that failed because the signing program did.
+// Output is what git and the program printed.
+type SigningError struct {
+	Program string
+	Output  string
+}
+
+func (e *SigningError) Error() string {
+	first, _, _ := strings.Cut(e.Output, "\n")
+	return "signing with " + e.Program + " failed: " + first
+}
+
+// LoadSigningConfig reads commit.gpgsign and gpg.format.
+func (s Service) LoadSigningConfig() (SigningConfig, error) {
+	sign, err := s.configValue("--bool", "commit.gpgsign")
+	if err != nil {
+		return SigningConfig{}, err
+	}
+	format, err := s.configValue("", "gpg.format")
+	if err != nil {
+		return SigningConfig{}, err
+	}
+	if format == "" {
+		format = "openpgp"
+	}
+	return SigningConfig{Sign: sign == "true", Format: format}, nil
+}
+
+// signingProgram is the program git runs to sign with the configured
+// gpg.format.
+func (s Service) signingProgram() string {
+	format, _ := s.configValue("", "gpg.format")
+	key, fallback := "gpg.program", "gpg"
+	switch format {
+	case "ssh":
+		key, fallback = "gpg.ssh.program", "ssh-keygen"
+	case "x509":
+		key, fallback = "gpg.x509.program", "gpgsm"
+	}
+	if program, _ := s.configValue("", key); program != "" {
+		return program
+	}
+	return fallback
+}
+
+// signingError turns a commit failure into a SigningError when git could not
+// write the commit object, which is how a failed signature surfaces.
+func (s Service) signingError(err error) error {
+	var cmdErr *CommandError
+	if !errors.As(err, &cmdErr) || !strings.Contains(cmdErr.Stderr, "failed to write commit object") {
+		return err
+	}
+	return &SigningError{Program: s.signingProgram(), Output: cmdErr.Stderr}
+}
@@ -0,0 +1,294 @@
+package git
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// maxSnapshots is how many discard snapshots are kept per repository; older
+// ones are removed when a new one is saved.
+const maxSnapshots = 20
+
+const snapshotFile = "snapshot.json"
+
+// errNotAFile is returned for directories and other paths a snapshot does
+// not copy, such as a nested repository.
+var errNotAFile = errors.New("not a regular file or symlink")
+
+// repoDirs returns the top of the work tree and the git directory.
+func (s Service) repoDirs() (root, gitDir string, err error) {
+	out, _, err := s.runner.RunRead("--no-optional-locks", "rev-parse", "--show-toplevel", "--absolute-git-dir")
+	if err != nil {
+		return "", "", err
+	}
+	root, gitDir, _ = strings.Cut(strings.TrimSpace(out), "\n")
+	return strings.TrimSpace(root), strings.TrimSpace(gitDir), nil
+}
+
+// WorkTreeRoot is the top of the work tree, which the paths of changes are
+// relative to.
+func (s Service) WorkTreeRoot() (string, error) {
+	root, _, err := s.repoDirs()
+	return root, err
+}
+
+// snapshotRef keeps the stash commit of snapshot id reachable, so that
+// git gc does not prune it while the snapshot is kept.
+func snapshotRef(id string) string {
+	return "refs/nit/snapshots/" + id
+}
+
+// snapshotsDir is where the snapshots of the repository at gitDir are kept,
+// one directory each.
+func snapshotsDir(gitDir string) string {
+	return filepath.Join(gitDir, "nit", "snapshots")
+}
+
+// saveSnapshot saves what a discard is about to throw away: the discarded
+// patch when there is one, and otherwise a stash-like commit of the tracked
+// changes plus copies of the untracked files, or of paths when given. Nothing
+// is saved when there is nothing to lose. It returns the git commands it ran.
+func (s Service) saveSnapshot(label, patch string, paths ...string) (string, error) {
+	root, gitDir, err := s.repoDirs()
+	if err != nil {
+		return "", err
+	}
+	now := time.Now()
+	snap := Snapshot{ID: now.Format("20060102-150405.000000000"), Created: now, Label: label, Patch: patch}
+	var cmds []string
+	if patch == "" && len(paths) == 0 {
+		_, _, headErr := s.runner.RunRead("--no-optional-locks", "rev-parse", "--verify", "-q", "HEAD")
+		listArgs := []string{"--no-optional-locks", "ls-files", "-z", "--full-name", "--others", "--exclude-standard"}
+		if headErr == nil {
+			out, cmd, err := s.runner.Run("stash", "create")
+			if err != nil {
+				return cmd, err
+			}
+			cmds = append(cmds, cmd)
+			snap.Stash = strings.TrimSpace(out)
+			if snap.Stash != "" {
+				_, cmd, err := s.runner.Run("update-ref", snapshotRef(snap.ID), snap.Stash)
+				cmds = append(cmds, cmd)
+				if err != nil {
+					return strings.Join(cmds, " && "), err
+				}
+			}
+		} else {
+			// Before the first commit there is nothing to stash against, so
+			// the files in the index are copied as well.
+			listArgs = append(listArgs, "--cached")
+		}
+		out, _, err := s.runner.RunRead(listArgs...)
+		if err != nil {
+			return strings.Join(cmds, " && "), err
+		}
+		for _, p := range strings.Split(out, "\x00") {
+			if p != "" {
+				paths = append(paths, p)
+			}
+		}
+	}
+	cmdLog := strings.Join(cmds, " && ")
+	dir := filepath.Join(snapshotsDir(gitDir), snap.ID)
+	for _, p := range paths {
+		if err := copyWorktreeFile(filepath.Join(root, p), filepath.Join(dir, "files", p)); err != nil {
+			if errors.Is(err, os.ErrNotExist) || errors.Is(err, errNotAFile) {
+				continue
+			}
+			return cmdLog, err
+		}
+		snap.Untracked = append(snap.Untracked, p)
+	}
+	if snap.Stash == "" && snap.Patch == "" && len(snap.Untracked) == 0 {
+		return cmdLog, nil
+	}
+	if err := writeSnapshot(dir, snap); err != nil {
+		return cmdLog, err
+	}
+	for _, old := range pruneSnapshots(snapshotsDir(gitDir)) {
+		if old.Stash != "" {
+			_, _, _ = s.runner.Run("update-ref", "-d", snapshotRef(old.ID))
+		}
+	}
+	return cmdLog, nil
+}
+
+// LoadSnapshots lists the discard snapshots of the repository, newest first.
+func (s Service) LoadSnapshots() ([]Snapshot, error) {
+	_, gitDir, err := s.repoDirs()
+	if err != nil {
+		return nil, err
+	}
+	return readSnapshots(snapshotsDir(gitDir))
+}
+
+// RestoreSnapshot brings back what the discard saved in snapshot id, or in
+// the newest snapshot when id is empty, and then forgets the snapshot. Files
+// that exist again in the work tree are left alone; their copies are kept
+// so that restoring again can bring them back once they are moved away,
+// unless the file in the work tree is the same as the copy.
+func (s Service) RestoreSnapshot(id string) (string, error) {
+	root, gitDir, err := s.repoDirs()
+	if err != nil {
+		return "", err
+	}
+	snaps, err := readSnapshots(snapshotsDir(gitDir))
+	if err != nil {
+		return "", err
+	}
+	var snap Snapshot
+	for _, sn := range snaps {
+		if id == "" || sn.ID == id {
+			snap = sn
+			break
+		}
+	}
+	if snap.ID == "" {
+		if id == "" {
+			return "", errors.New("no discard to undo")
+		}
+		return "", fmt.Errorf("snapshot %s not found", id)
+	}
+	dir := filepath.Join(snapshotsDir(gitDir), snap.ID)
+	var cmd string
+	switch {
+	case snap.Patch != "":
+		cmd, err = s.applyPatch(snap.Patch)
+	case snap.Stash != "":
+		_, cmd, err = s.runner.Run("stash", "apply", "--index", snap.Stash)
+		if err == nil {
+			var refCmd string
+			_, refCmd, err = s.runner.Run("update-ref", "-d", snapshotRef(snap.ID))
+			cmd += " && " + refCmd
+		}
+	}
+	if err != nil {
+		return cmd, err
+	}
+	var kept []string
+	for _, p := range snap.Untracked {
+		dst := filepath.Join(root, p)
+		if _, err := os.Lstat(dst); err == nil {
+			if !sameWorktreeFile(filepath.Join(dir, "files", p), dst) {
+				kept = append(kept, p)
+			}
+			continue
+		}
+		if err := copyWorktreeFile(filepath.Join(dir, "files", p), dst); err != nil {
+			return cmd, err
+		}
+	}
+	if len(kept) == 0 {
+		return cmd, os.RemoveAll(dir)
+	}
+	snap.Stash, snap.Patch, snap.Untracked = "", "", kept
+	if err := writeSnapshot(dir, snap); err != nil {
+		return cmd, err
+	}
+	return cmd, fmt.Errorf("%d file(s) exist again and were not restored: %s", len(kept), strings.Join(kept, ", "))
+}
+
+func writeSnapshot(dir string, snap Snapshot) error {
+	data, err := json.MarshalIndent(snap, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, snapshotFile), data, 0o644)
+}
+
+// readSnapshots reads the snapshots under dir, newest first, skipping any
+// that cannot be read.
+func readSnapshots(dir string) ([]Snapshot, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var snaps []Snapshot
+	for _, e := range entries {
+		data, err := os.ReadFile(filepath.Join(dir, e.Name(), snapshotFile))
+		if err != nil {
+			continue
+		}
+		var snap Snapshot
+		if json.Unmarshal(data, &snap) != nil || snap.ID != e.Name() {
+			continue
+		}
+		snaps = append(snaps, snap)
+	}
+	sort.Slice(snaps, func(i, j int) bool { return snaps[i].Created.After(snaps[j].Created) })
+	return snaps, nil
+}
+
+// pruneSnapshots removes all but the newest maxSnapshots snapshots under dir
+// and returns the removed ones.
+func pruneSnapshots(dir string) []Snapshot {
+	snaps, err := readSnapshots(dir)
+	if err != nil || len(snaps) <= maxSnapshots {
+		return nil
+	}
+	for _, snap := range snaps[maxSnapshots:] {
+		_ = os.RemoveAll(filepath.Join(dir, snap.ID))
+	}
+	return snaps[maxSnapshots:]
+}
+
+// sameWorktreeFile reports whether a and b are both regular files with the
+// same content, or symlinks to the same target.
+func sameWorktreeFile(a, b string) bool {
+	ia, err := os.Lstat(a)
+	if err != nil {
+		return false
+	}
+	ib, err := os.Lstat(b)
+	if err != nil || ia.Mode().Type() != ib.Mode().Type() {
+		return false
+	}
+	if ia.Mode()&os.ModeSymlink != 0 {
+		ta, errA := os.Readlink(a)
+		tb, errB := os.Readlink(b)
+		return errA == nil && errB == nil && ta == tb
+	}
+	da, errA := os.ReadFile(a)
+	db, errB := os.ReadFile(b)
+	return errA == nil && errB == nil && bytes.Equal(da, db)
+}
+
+// copyWorktreeFile copies a regular file or a symlink, creating the parent
+// directories of dst.
+func copyWorktreeFile(src, dst string) error {
+	info, err := os.Lstat(src)
+	if err != nil {
+		return err
+	}
+	if info.Mode()&os.ModeSymlink == 0 && !info.Mode().IsRegular() {
+		return errNotAFile
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return err
+	}
+	if info.Mode()&os.ModeSymlink != 0 {
+		target, err := os.Readlink(src)
+		if err != nil {
+			return err
+		}
+		return os.Symlink(target, dst)
+	}
+	data, err := os.ReadFile(src)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(dst, data, info.Mode().Perm())
+}
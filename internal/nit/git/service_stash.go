@@ -0,0 +1,46 @@
+package git
+
+import "strings"
+
+func (s Service) LoadStashes() ([]Stash, error) {
+	out, _, err := s.runner.RunRead("--no-optional-locks", "stash", "list", "--format=%gd%x00%H%x00%gs")
+	if err != nil {
+		return nil, err
+	}
+	stashes := []Stash{}
+	for _, line := range strings.Split(out, "\n") {
+		f := strings.SplitN(line, "\x00", 3)
+		if len(f) < 3 {
+			continue
+		}
+		stashes = append(stashes, Stash{Ref: f[0], Hash: f[1], Message: f[2]})
+	}
+	return stashes, nil
+}
+
+func (s Service) StashPush(message string, includeUntracked bool) (string, error) {
+	args := []string{"stash", "push"}
+	if includeUntracked {
+		args = append(args, "--include-untracked")
+	}
+	if msg := strings.TrimSpace(message); msg != "" {
+		args = append(args, "-m", msg)
+	}
+	_, cmd, err := s.runner.Run(args...)
+	return cmd, err
+}
+
+func (s Service) StashApply(ref string) (string, error) {
+	_, cmd, err := s.runner.Run("stash", "apply", ref)
+	return cmd, err
+}
+
+func (s Service) StashPop(ref string) (string, error) {
+	_, cmd, err := s.runner.Run("stash", "pop", ref)
+	return cmd, err
+}
+
+func (s Service) StashDrop(ref string) (string, error) {
+	_, cmd, err := s.runner.Run("stash", "drop", ref)
+	return cmd, err
+}
@@ -0,0 +1,55 @@
+package git
+
+import "time"
+
+// WithStream makes commit, fetch, pull and push report their output and
+// progress to st while they run, and stop when its context is cancelled.
+func (s Service) WithStream(st Stream) Service {
+	s.stream = st
+	return s
+}
+
+// WithFetchTimeout stops fetches that run longer than d. Zero means no limit.
+func (s Service) WithFetchTimeout(d time.Duration) Service {
+	s.fetchTimeout = d
+	return s
+}
+
+// WithPullTimeout stops pulls that run longer than d. Zero means no limit.
+func (s Service) WithPullTimeout(d time.Duration) Service {
+	s.pullTimeout = d
+	return s
+}
+
+// WithPushTimeout stops pushes that run longer than d. Zero means no limit.
+func (s Service) WithPushTimeout(d time.Duration) Service {
+	s.pushTimeout = d
+	return s
+}
+
+// runHooked runs a command that may trigger hooks. It has no timeout, since
+// hooks may take as long as they need.
+func (s Service) runHooked(args ...string) (string, string, error) {
+	return s.runner.RunStreaming(s.stream, 0, args...)
+}
+
+// runHookedNoEditor is runHooked for commands that would open an editor for
+// the commit message, such as "merge --continue"; the message git prepared is
+// kept.
+func (s Service) runHookedNoEditor(args ...string) (string, string, error) {
+	return s.runner.RunStreamingWithEnv(s.stream, 0, []string{nonInteractiveEditor}, args...)
+}
+
+// runRemote runs a command that talks to a remote, such as fetch, pull or
+// push, stopping it after timeout unless that is zero. It asks git for
+// progress reports when the stream wants them.
+func (s Service) runRemote(timeout time.Duration, args ...string) (string, string, error) {
+	return s.runner.RunStreaming(s.stream, timeout, s.withProgress(args)...)
+}
+
+func (s Service) withProgress(args []string) []string {
+	if s.stream.Progress == nil {
+		return args
+	}
+	return append([]string{args[0], "--progress"}, args[1:]...)
+}
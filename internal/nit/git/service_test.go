@@ -1,47 +1,328 @@
 package git
 
-import "testing"
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
 
-func TestPrettifyGraphLine(t *testing.T) {
+func TestParseGraphLog(t *testing.T) {
+	raw := "aaaa1111\x00aaaa111\x00bbbb2222 cccc3333\x00HEAD -> main, tag: v1\x00Ann\x002026-02-23T10:00:00+01:00\x00G\x00Merge / keep slash\n" +
+		"cccc3333\x00cccc333\x00\x00\x00Bob\x002026-02-22T09:00:00Z\x00\x00init"
+	got := parseGraphLog(raw)
+	if len(got) != 2 {
+		t.Fatalf("parseGraphLog() returned %d commits, want 2", len(got))
+	}
+	c := got[0]
+	if c.Hash != "aaaa1111" || c.ShortHash != "aaaa111" || c.Author != "Ann" || c.Subject != "Merge / keep slash" {
+		t.Fatalf("parseGraphLog()[0] = %+v", c)
+	}
+	if !reflect.DeepEqual(c.Parents, []string{"bbbb2222", "cccc3333"}) {
+		t.Fatalf("parents = %q", c.Parents)
+	}
+	if !reflect.DeepEqual(c.Refs, []string{"HEAD -> main", "tag: v1"}) {
+		t.Fatalf("refs = %q", c.Refs)
+	}
+	if c.Date.UTC().Hour() != 9 {
+		t.Fatalf("date = %v", c.Date)
+	}
+	if c.Signature != SignatureGood {
+		t.Fatalf("signature = %q", c.Signature)
+	}
+	if len(got[1].Parents) != 0 || len(got[1].Refs) != 0 || got[1].Signature != "" {
+		t.Fatalf("root commit = %+v", got[1])
+	}
+}
+
+func TestLayoutGraph(t *testing.T) {
 	tests := []struct {
-		name string
-		in   string
-		want string
+		name    string
+		commits []Commit
+		want    []string
 	}{
 		{
-			name: "commit line with graph prefix and hash",
-			in:   "| * 8fd9242 message / keep slash in message",
-			want: "│ ● 8fd9242 message / keep slash in message",
+			name: "linear history",
+			commits: []Commit{
+				{Hash: "c", Parents: []string{"b"}},
+				{Hash: "b", Parents: []string{"a"}},
+				{Hash: "a"},
+			},
+			want: []string{"●", "●", "●"},
 		},
 		{
-			name: "continuation graph line without hash",
-			in:   "|\\  ",
-			want: "│╲  ",
+			name: "merge of a side branch",
+			commits: []Commit{
+				{Hash: "m", Parents: []string{"main", "feat"}},
+				{Hash: "feat", Parents: []string{"base"}},
+				{Hash: "main", Parents: []string{"base"}},
+				{Hash: "base"},
+			},
+			want: []string{"●─╮", "│ ●", "● │", "●─╯"},
 		},
 		{
-			name: "underscore bridge in graph",
-			in:   "| *- not expected but _ bridge",
-			want: "│ ●- not expected but _ bridge",
+			name: "two unmerged tips",
+			commits: []Commit{
+				{Hash: "x", Parents: []string{"base"}},
+				{Hash: "y", Parents: []string{"base"}},
+				{Hash: "base"},
+			},
+			want: []string{"●", "│ ●", "●─╯"},
 		},
 		{
-			name: "line without graph prefix stays unchanged",
-			in:   "feature/test path and * text",
-			want: "feature/test path and * text",
-		},
-		{
-			name: "graph only with diagonal and spaces",
-			in:   "| |/ ",
-			want: "│ │╱ ",
+			name: "merge edge crossing a lane",
+			commits: []Commit{
+				{Hash: "t", Parents: []string{"a"}},
+				{Hash: "m", Parents: []string{"b", "c"}},
+				{Hash: "a", Parents: []string{"c"}},
+				{Hash: "b", Parents: []string{"c"}},
+				{Hash: "c"},
+			},
+			want: []string{"●", "│ ●─╮", "● │ │", "│ ● │", "●─┴─╯"},
 		},
 	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := layoutGraph(tt.commits)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("layoutGraph() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseBranchRefs(t *testing.T) {
+	raw := " \x00refs/heads/feat\x00feat\x00origin/feat\x00[gone]\n" +
+		"*\x00refs/heads/main\x00main\x00origin/main\x00[ahead 2, behind 5]\n" +
+		" \x00refs/remotes/origin/HEAD\x00origin\x00\x00\n" +
+		" \x00refs/remotes/origin/main\x00origin/main\x00\x00\n" +
+		" \x00refs/tags/v1\x00v1\x00\x00\n"
+	want := []Branch{
+		{Kind: BranchLocal, Name: "feat", Upstream: "origin/feat", UpstreamGone: true},
+		{Kind: BranchLocal, Name: "main", Head: true, Upstream: "origin/main", Ahead: 2, Behind: 5},
+		{Kind: BranchRemote, Name: "origin/main"},
+		{Kind: BranchTag, Name: "v1"},
+	}
+	if got := parseBranchRefs(raw); !reflect.DeepEqual(got, want) {
+		t.Fatalf("parseBranchRefs() = %+v, want %+v", got, want)
+	}
+}
+
+func TestRebaseTodoScript(t *testing.T) {
+	items := []RebaseTodoItem{
+		{Action: RebasePick, Hash: "aaa", Subject: "first"},
+		{Action: RebaseReword, Hash: "bbb", Subject: "second", Message: "it's new\n\nBody kept.\n\nRefs: #1"},
+		{Action: RebaseFixup, Hash: "ccc", Subject: "third"},
+		{Action: RebaseReword, Hash: "ddd", Subject: "fourth"},
+	}
+	want := "pick aaa first\n" +
+		"pick bbb second\n" +
+		"exec printf '%s\\n' 'it'\\''s new' '' 'Body kept.' '' 'Refs: #1' | git commit --amend --only --allow-empty --quiet -F -\n" +
+		"fixup ccc third\n" +
+		"reword ddd fourth\n"
+	if got := RebaseTodoScript(items); got != want {
+		t.Fatalf("RebaseTodoScript() = %q, want %q", got, want)
+	}
+}
+
+func TestParseRebaseTodo(t *testing.T) {
+	out := "aaa\x1fa\x1ffirst\x1ffirst\n\nBody line.\n\nRefs: #1\n\x1e\n" +
+		"bbb\x1fb\x1fsecond\x1fsecond\n\x1e"
+	want := []RebaseTodoItem{
+		{Action: RebasePick, Hash: "aaa", ShortHash: "a", Subject: "first", FullMessage: "first\n\nBody line.\n\nRefs: #1"},
+		{Action: RebasePick, Hash: "bbb", ShortHash: "b", Subject: "second", FullMessage: "second"},
+	}
+	if got := parseRebaseTodo(out); !reflect.DeepEqual(got, want) {
+		t.Fatalf("parseRebaseTodo() = %+v, want %+v", got, want)
+	}
+}
 
+func TestLineWriter(t *testing.T) {
+	var got []string
+	var progress []Progress
+	w := &lineWriter{
+		onLine:     func(line string) { got = append(got, line) },
+		onProgress: func(p Progress) { progress = append(progress, p) },
+	}
+	w.Write([]byte("lint: che"))
+	w.Write([]byte("cking\r"))
+	w.Write([]byte("\nReceiving objects:  45% (9/20)\rReceiving objects: 100% (20/20), done.\nremote: Counting objects: 50% (1/2)\r"))
+	w.Write([]byte("\x1b[KSuccessfully rebased.\n"))
+	w.Write([]byte("no newline"))
+	w.Flush()
+	want := []string{"lint: checking", "Receiving objects: 100% (20/20), done.", "Successfully rebased.", "no newline"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("lines = %q, want %q", got, want)
+	}
+	wantProgress := []Progress{
+		{Phase: "Receiving objects", Percent: 45, Done: 9, Total: 20},
+		{Phase: "Receiving objects", Percent: 100, Done: 20, Total: 20},
+		{Phase: "Counting objects", Percent: 50, Done: 1, Total: 2},
+	}
+	if !reflect.DeepEqual(progress, wantProgress) {
+		t.Fatalf("progress = %+v, want %+v", progress, wantProgress)
+	}
+}
+
+func TestRepoState(t *testing.T) {
+	tests := []struct {
+		name        string
+		files       map[string]string
+		want        RepoState
+		step, steps int
+	}{
+		{name: "clean", want: RepoClean},
+		{name: "merge", files: map[string]string{"MERGE_HEAD": "abc\n"}, want: RepoMerging},
+		{name: "rebase wins over cherry-pick", files: map[string]string{"rebase-merge/msgnum": "2\n", "rebase-merge/end": "5\n", "CHERRY_PICK_HEAD": "abc\n"}, want: RepoRebasing, step: 2, steps: 5},
+		{name: "am-style rebase", files: map[string]string{"rebase-apply/next": "1\n", "rebase-apply/last": "3\n"}, want: RepoRebasing, step: 1, steps: 3},
+		{name: "cherry-pick", files: map[string]string{"CHERRY_PICK_HEAD": "abc\n"}, want: RepoCherryPicking},
+		{name: "revert", files: map[string]string{"REVERT_HEAD": "abc\n"}, want: RepoReverting},
+		{name: "bisect", files: map[string]string{"BISECT_LOG": "# bad: [abc]\n"}, want: RepoBisecting},
+	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := prettifyGraphLine(tt.in)
-			if got != tt.want {
-				t.Fatalf("prettifyGraphLine(%q) = %q, want %q", tt.in, got, tt.want)
+			dir := t.TempDir()
+			for name, content := range tt.files {
+				path := filepath.Join(dir, name)
+				if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			state, step, steps := repoState(dir)
+			if state != tt.want || step != tt.step || steps != tt.steps {
+				t.Fatalf("repoState() = %q %d/%d, want %q %d/%d", state, step, steps, tt.want, tt.step, tt.steps)
 			}
 		})
 	}
 }
 
+func TestSnapshotsNewestFirstAndPruned(t *testing.T) {
+	dir := t.TempDir()
+	start := time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC)
+	for i := 0; i < maxSnapshots+2; i++ {
+		created := start.Add(time.Duration(i) * time.Minute)
+		snap := Snapshot{ID: created.Format("20060102-150405"), Created: created, Label: "Discard all changes", Stash: "abc"}
+		if err := writeSnapshot(filepath.Join(dir, snap.ID), snap); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "broken"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if pruned := pruneSnapshots(dir); len(pruned) != 2 {
+		t.Fatalf("pruned %d snapshots, want 2", len(pruned))
+	}
+	snaps, err := readSnapshots(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(snaps) != maxSnapshots {
+		t.Fatalf("kept %d snapshots, want %d", len(snaps), maxSnapshots)
+	}
+	if want := start.Add(time.Duration(maxSnapshots+1) * time.Minute); !snaps[0].Created.Equal(want) {
+		t.Fatalf("newest = %v, want %v", snaps[0].Created, want)
+	}
+	if oldest := snaps[len(snaps)-1].Created; !oldest.Equal(start.Add(2 * time.Minute)) {
+		t.Fatalf("oldest kept = %v", oldest)
+	}
+}
+
+func TestHardResetSavesSnapshot(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not found")
+	}
+	dir := t.TempDir()
+	for _, kv := range [][2]string{
+		{"GIT_CONFIG_NOSYSTEM", "1"},
+		{"GIT_CONFIG_GLOBAL", os.DevNull},
+		{"GIT_AUTHOR_NAME", "nit"},
+		{"GIT_AUTHOR_EMAIL", "nit@example.com"},
+		{"GIT_COMMITTER_NAME", "nit"},
+		{"GIT_COMMITTER_EMAIL", "nit@example.com"},
+	} {
+		t.Setenv(kv[0], kv[1])
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+	git := func(args ...string) string {
+		t.Helper()
+		out, err := exec.Command("git", args...).CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+		return string(out)
+	}
+	write := func(name, content string) {
+		t.Helper()
+		if err := os.WriteFile(name, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	git("init", "-q")
+	write("a.txt", "one\n")
+	write("b.txt", "one\n")
+	git("add", ".")
+	git("commit", "-q", "-m", "first")
+	write("b.txt", "two\n")
+	git("commit", "-q", "-am", "second")
+	write("a.txt", "edited\n")
+	write("new.txt", "untracked\n")
+
+	s := NewService(NewRunner(RunnerOptions{ReadTimeout: 10 * time.Second, WriteTimeout: 10 * time.Second}))
+	if _, err := s.Reset("HEAD~1", ResetHard); err != nil {
+		t.Fatal(err)
+	}
+	snaps, err := s.LoadSnapshots()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(snaps) != 1 || snaps[0].Stash == "" {
+		t.Fatalf("snapshots = %+v, want one with a stash", snaps)
+	}
+	if _, err := s.RestoreSnapshot(""); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile("a.txt"); string(data) != "edited\n" {
+		t.Fatalf("a.txt = %q after restore", data)
+	}
+	if data, _ := os.ReadFile("b.txt"); string(data) != "one\n" {
+		t.Fatalf("b.txt = %q after restore", data)
+	}
+	if refs := git("for-each-ref", "refs/nit"); refs != "" {
+		t.Fatalf("snapshot ref left behind: %s", refs)
+	}
+}
+
+func TestGitignorePattern(t *testing.T) {
+	tests := map[string]string{
+		"build/out.log":   "/build/out.log",
+		"#notes.txt":      "/#notes.txt",
+		"a*b?[c].txt":     `/a\*b\?\[c].txt`,
+		"trailing space ": `/trailing space\ `,
+	}
+	for path, want := range tests {
+		if got := gitignorePattern(path); got != want {
+			t.Errorf("gitignorePattern(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
+
+func TestChangeEntryOrigPath(t *testing.T) {
+	if got := ParseChangeLine("R  old/a.go -> new/a.go").OrigPath(); got != "old/a.go" {
+		t.Fatalf("OrigPath() = %q, want old/a.go", got)
+	}
+	if got := ParseChangeLine(" M a -> b.go").OrigPath(); got != "" {
+		t.Fatalf("OrigPath() of a modified file = %q, want empty", got)
+	}
+}
@@ -2,13 +2,20 @@ package git
 
 import (
 "errors"
+"fmt"
 "strings"
 )
 
+// CommitOptions tweak a commit. Sign adds -S and NoSign --no-gpg-sign; with
+// neither, git follows commit.gpgsign. NoVerify skips the pre-commit and
+// commit-msg hooks.
 type CommitOptions struct {
-All     bool
-Amend   bool
-Signoff bool
+All      bool
+Amend    bool
+Signoff  bool
+Sign     bool
+NoSign   bool
+NoVerify bool
 }
 
 func (s Service) StagePath(path string) (string, error) {
@@ -35,14 +42,23 @@ return s.runWithFallback(
 )
 }
 
+// DiscardAll resets tracked files and deletes untracked ones, after saving a
+// snapshot of both that RestoreSnapshot can bring back. Nothing is discarded
+// when the snapshot cannot be saved.
 func (s Service) DiscardAll() (string, error) {
-cmdLog := ""
+cmdLog, err := s.saveSnapshot("Discard all changes", "")
+if err != nil {
+return cmdLog, fmt.Errorf("nothing was discarded, saving a snapshot failed: %w", err)
+}
 resetErr := error(nil)
-if _, resetCmd, err := s.runner.Run("reset", "--hard", "HEAD"); err == nil {
-cmdLog = resetCmd
-} else {
+_, resetCmd, err := s.runner.Run("reset", "--hard", "HEAD")
+if err != nil {
 resetErr = err
+}
 if resetCmd != "" {
+if cmdLog != "" {
+cmdLog += " && " + resetCmd
+} else {
 cmdLog = resetCmd
 }
 }
@@ -96,8 +112,19 @@ args = append(args, "--amend")
 if opts.Signoff {
 args = append(args, "--signoff")
 }
+if opts.Sign {
+args = append(args, "-S")
+} else if opts.NoSign {
+args = append(args, "--no-gpg-sign")
+}
+if opts.NoVerify {
+args = append(args, "--no-verify")
+}
 args = append(args, "-m", msg)
-_, commitCmd, err := s.runner.Run(args...)
+_, commitCmd, err := s.runHooked(args...)
+if err != nil && !opts.NoSign {
+err = s.signingError(err)
+}
 if cmdLog != "" && commitCmd != "" {
 cmdLog += " && " + commitCmd
 } else if commitCmd != "" {
@@ -2,7 +2,7 @@ package git
 
 import (
 	"errors"
-	"strconv"
+	"fmt"
 	"strings"
 )
 
@@ -35,43 +35,302 @@ func (s Service) SwitchBranch(name string) (string, error) {
 	)
 }
 
-func (s Service) PushCurrentBranchUpstream() (string, error) {
-	_, cmd, err := s.runner.Run("push", "-u", "origin", "HEAD")
+// DeleteBranch deletes a local branch. Without force git refuses to delete a
+// branch that is not merged into its upstream or HEAD.
+func (s Service) DeleteBranch(name string, force bool) (string, error) {
+	branch := strings.TrimSpace(name)
+	if branch == "" {
+		return "", errors.New("branch name is empty")
+	}
+	flag := "-d"
+	if force {
+		flag = "-D"
+	}
+	_, cmd, err := s.runner.Run("branch", flag, branch)
+	return cmd, err
+}
+
+// LoadUnmergedCommits lists the commits of a local branch that no other ref
+// reaches, newest first: the ones a force delete leaves only in the reflog.
+func (s Service) LoadUnmergedCommits(name string) ([]Commit, error) {
+	branch := strings.TrimSpace(name)
+	if branch == "" {
+		return nil, errors.New("branch name is empty")
+	}
+	ref := "refs/heads/" + branch
+	out, _, err := s.runner.RunRead("--no-optional-locks", "log", graphLogFormat, ref, "--not", "--exclude="+ref, "--exclude="+snapshotRef("*"), "--all")
+	if err != nil {
+		return nil, err
+	}
+	return parseGraphLog(out), nil
+}
+
+func (s Service) RenameBranch(oldName, newName string) (string, error) {
+	from := strings.TrimSpace(oldName)
+	to := strings.TrimSpace(newName)
+	if from == "" || to == "" {
+		return "", errors.New("branch name is empty")
+	}
+	_, cmd, err := s.runner.Run("branch", "-m", from, to)
 	return cmd, err
 }
 
-func (s Service) Pull() (string, error) {
-	_, cmd, err := s.runner.Run("pull")
+func (s Service) SetUpstream(name, upstream string) (string, error) {
+	branch := strings.TrimSpace(name)
+	target := strings.TrimSpace(upstream)
+	if branch == "" {
+		return "", errors.New("branch name is empty")
+	}
+	if target == "" {
+		return "", errors.New("upstream is empty")
+	}
+	_, cmd, err := s.runner.Run("branch", "--set-upstream-to="+target, branch)
+	return cmd, err
+}
+
+func (s Service) UnsetUpstream(name string) (string, error) {
+	branch := strings.TrimSpace(name)
+	if branch == "" {
+		return "", errors.New("branch name is empty")
+	}
+	_, cmd, err := s.runner.Run("branch", "--unset-upstream", branch)
 	return cmd, err
 }
 
-func (s Service) Push() (string, error) {
+// DeleteRemoteBranch deletes the branch that name tracks from its remote.
+func (s Service) DeleteRemoteBranch(name string) (string, error) {
+	branch := strings.TrimSpace(name)
+	if branch == "" {
+		return "", errors.New("branch name is empty")
+	}
+	out, _, err := s.runner.RunRead("--no-optional-locks", "for-each-ref", "--format=%(upstream:remotename)%00%(upstream:remoteref)", "refs/heads/"+branch)
+	if err != nil {
+		return "", err
+	}
+	parts := strings.SplitN(strings.TrimSpace(out), "\x00", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", errors.New(branch + " has no upstream branch")
+	}
+	_, cmd, err := s.runRemote(s.pushTimeout, "push", parts[0], "--delete", strings.TrimPrefix(parts[1], "refs/heads/"))
+	return cmd, err
+}
+
+// TrackRemoteBranch creates the local branch name tracking remoteBranch, such
+// as "origin/feature", and switches to it.
+func (s Service) TrackRemoteBranch(remoteBranch, name string) (string, error) {
+	remote := strings.TrimSpace(remoteBranch)
+	branch := strings.TrimSpace(name)
+	if remote == "" || branch == "" {
+		return "", errors.New("branch name is empty")
+	}
+	return s.runWithFallback(
+		[]string{"switch", "-c", branch, "--track", remote},
+		[]string{"checkout", "-b", branch, "--track", remote},
+	)
+}
+
+// CreateTag tags ref, or HEAD when ref is empty. A non-empty message makes an
+// annotated tag; otherwise the tag is lightweight.
+func (s Service) CreateTag(name, ref, message string) (string, error) {
+	tag := strings.TrimSpace(name)
+	if tag == "" {
+		return "", errors.New("tag name is empty")
+	}
+	args := []string{"tag"}
+	if strings.TrimSpace(message) != "" {
+		args = append(args, "-a", "-m", message)
+	}
+	args = append(args, tag)
+	if ref = strings.TrimSpace(ref); ref != "" {
+		args = append(args, ref)
+	}
+	_, cmd, err := s.runner.Run(args...)
+	return cmd, err
+}
+
+func (s Service) DeleteTag(name string) (string, error) {
+	tag := strings.TrimSpace(name)
+	if tag == "" {
+		return "", errors.New("tag name is empty")
+	}
+	_, cmd, err := s.runner.Run("tag", "-d", tag)
+	return cmd, err
+}
+
+func (s Service) PushTag(name string) (string, error) {
+	tag := strings.TrimSpace(name)
+	if tag == "" {
+		return "", errors.New("tag name is empty")
+	}
+	_, cmd, err := s.runRemote(s.pushTimeout, "push", "origin", "refs/tags/"+tag)
+	return cmd, err
+}
+
+func (s Service) PushCurrentBranchUpstream() (string, error) {
+	_, cmd, err := s.runRemote(s.pushTimeout, "push", "-u", "origin", "HEAD")
+	return cmd, err
+}
+
+// Pull pulls into the current branch. With noVerify the pre-merge-commit and
+// commit-msg hooks of the merge are skipped. Conflicts are reported as a
+// MergeConflictError.
+func (s Service) Pull(noVerify bool) (string, error) {
+	args := []string{"pull"}
+	if noVerify {
+		args = append(args, "--no-verify")
+	}
+	_, cmd, err := s.runRemote(s.pullTimeout, args...)
+	return cmd, s.conflictError(cmd, err, RepoMerging)
+}
+
+// Push pushes the current branch. With noVerify the pre-push hook is skipped.
+func (s Service) Push(noVerify bool) (string, error) {
 	if err := s.ensureHasOutgoingCommits(); err != nil {
 		return "", err
 	}
-	_, cmd, err := s.runner.Run("push")
+	args := []string{"push"}
+	if noVerify {
+		args = append(args, "--no-verify")
+	}
+	_, cmd, err := s.runRemote(s.pushTimeout, args...)
 	return cmd, err
 }
 
 func (s Service) Fetch() (string, error) {
-	_, cmd, err := s.runner.Run("fetch")
+	_, cmd, err := s.runRemote(s.fetchTimeout, "fetch")
 	return cmd, err
 }
 
 func (s Service) ensureHasOutgoingCommits() error {
-	if _, _, err := s.runner.Run("--no-optional-locks", "rev-parse", "--abbrev-ref", "--symbolic-full-name", "@{u}"); err != nil {
+	if _, _, err := s.runner.RunRead("--no-optional-locks", "rev-parse", "--abbrev-ref", "--symbolic-full-name", "@{u}"); err != nil {
 		return nil
 	}
-	out, _, err := s.runner.Run("--no-optional-locks", "rev-list", "--count", "@{u}..HEAD")
+	ahead, _, err := s.aheadBehind()
 	if err != nil {
 		return nil
 	}
-	n, convErr := strconv.Atoi(strings.TrimSpace(out))
-	if convErr != nil {
-		return nil
-	}
-	if n == 0 {
+	if ahead == 0 {
 		return errors.New("nothing to push")
 	}
 	return nil
 }
+
+// autostashPrefix marks stashes nit creates when switching away from a branch
+// with local changes; the rest of the message is the branch name.
+const autostashPrefix = "nit-autostash: "
+
+// IsLocalChangesError reports whether a switch failed because local changes
+// would be overwritten by the checkout.
+func IsLocalChangesError(err error) bool {
+	if err == nil {
+		return false
+	}
+	text := err.Error()
+	return strings.Contains(text, "would be overwritten by checkout") ||
+		strings.Contains(text, "stash them before you switch branches")
+}
+
+// LocalChangesFiles lists the paths git named in a local changes error.
+func LocalChangesFiles(err error) []string {
+	if err == nil {
+		return nil
+	}
+	var files []string
+	for _, line := range strings.Split(err.Error(), "\n") {
+		if strings.HasPrefix(line, "\t") {
+			files = append(files, strings.TrimSpace(line))
+		}
+	}
+	return files
+}
+
+// StashAndSwitch stashes all local changes, including untracked files, under
+// an autostash message for the current branch and switches to name, restoring
+// any autostash left on name. If the switch fails, the stash it made, and only
+// that one, is popped again.
+func (s Service) StashAndSwitch(name string) (string, error) {
+	branch := strings.TrimSpace(name)
+	if branch == "" {
+		return "", errors.New("branch name is empty")
+	}
+	current, _, err := s.runner.RunRead("--no-optional-locks", "branch", "--show-current")
+	if err != nil {
+		return "", err
+	}
+	current = strings.TrimSpace(current)
+	if current == "" {
+		return "", errors.New("cannot autostash on a detached HEAD")
+	}
+	before := s.newestStash()
+	_, stashCmd, err := s.runner.Run("stash", "push", "--include-untracked", "-m", autostashPrefix+current)
+	if err != nil {
+		return stashCmd, err
+	}
+	// A clean tree makes stash push succeed without stashing anything.
+	stashed := s.newestStash()
+	if stashed == before {
+		stashed = ""
+	}
+	switchCmd, err := s.SwitchBranch(branch)
+	cmdLog := joinCommandLog(stashCmd, switchCmd)
+	if err != nil {
+		if stashed == "" {
+			return cmdLog, err
+		}
+		if s.newestStash() != stashed {
+			return cmdLog, fmt.Errorf("%w; the autostash %s is no longer the newest stash, restore it from the Stash panel", err, stashed[:min(7, len(stashed))])
+		}
+		_, popCmd, _ := s.runner.Run("stash", "pop", "stash@{0}")
+		return joinCommandLog(cmdLog, popCmd), err
+	}
+	popCmd, err := s.PopAutostash(branch)
+	return joinCommandLog(cmdLog, popCmd), err
+}
+
+// newestStash is the commit of stash@{0}, or "" when there are no stashes.
+func (s Service) newestStash() string {
+	out, _, err := s.runner.RunRead("--no-optional-locks", "rev-parse", "-q", "--verify", "refs/stash")
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(out)
+}
+
+// SwitchBranchCarry switches to name and merges local changes into the new
+// branch.
+func (s Service) SwitchBranchCarry(name string) (string, error) {
+	branch := strings.TrimSpace(name)
+	if branch == "" {
+		return "", errors.New("branch name is empty")
+	}
+	return s.runWithFallback(
+		[]string{"switch", "--merge", branch},
+		[]string{"checkout", "--merge", branch},
+	)
+}
+
+// PopAutostash restores the newest autostash made on branch, if any. It
+// returns an empty command when there is nothing to restore.
+func (s Service) PopAutostash(branch string) (string, error) {
+	stashes, err := s.LoadStashes()
+	if err != nil {
+		return "", err
+	}
+	for _, st := range stashes {
+		if strings.HasSuffix(st.Message, ": "+autostashPrefix+branch) {
+			return s.StashPop(st.Ref)
+		}
+	}
+	return "", nil
+}
+
+func joinCommandLog(a, b string) string {
+	switch {
+	case a == "":
+		return b
+	case b == "":
+		return a
+	default:
+		return a + " && " + b
+	}
+}
@@ -0,0 +1,141 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// maxFileHistory is how many commits LoadFileHistory lists.
+const maxFileHistory = 200
+
+// DiscardFile throws away every change to path, staged or not: a tracked
+// file goes back to its HEAD version and an untracked one is deleted. orig is
+// the old path of a renamed file, which is brought back as well. What is
+// discarded is saved in a snapshot first.
+func (s Service) DiscardFile(path, orig string) (string, error) {
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return "", errors.New("path is empty")
+	}
+	paths := []string{path}
+	if orig = strings.TrimSpace(orig); orig != "" {
+		paths = append(paths, orig)
+	}
+	// Status paths are relative to the top of the work tree, wherever nit
+	// runs from.
+	specs := make([]string, 0, len(paths))
+	for _, p := range paths {
+		specs = append(specs, ":(top,literal)"+p)
+	}
+	untracked, _, err := s.runner.RunRead(append([]string{"--no-optional-locks", "ls-files", "--others", "--exclude-standard", "--"}, specs...)...)
+	if err != nil {
+		return "", err
+	}
+	_, _, headErr := s.runner.RunRead("--no-optional-locks", "rev-parse", "--verify", "-q", "HEAD")
+	label := "Discard " + path
+	if strings.TrimSpace(untracked) != "" || headErr != nil {
+		// Untracked files, and every file before the first commit, have no
+		// version to go back to: they are copied into the snapshot and removed.
+		if _, err := s.saveSnapshot(label, "", paths...); err != nil {
+			return "", fmt.Errorf("nothing was discarded, saving a snapshot failed: %w", err)
+		}
+		if strings.TrimSpace(untracked) != "" {
+			_, cmd, err := s.runner.Run(append([]string{"clean", "-f", "--"}, specs...)...)
+			return cmd, err
+		}
+		_, cmd, err := s.runner.Run(append([]string{"rm", "-f", "-q", "--"}, specs...)...)
+		return cmd, err
+	}
+	patch, _, err := s.runner.RunRead(append([]string{"--no-optional-locks", "diff", "--binary", "HEAD", "--"}, specs...)...)
+	if err != nil {
+		return "", err
+	}
+	if patch != "" {
+		// The runner trims the output, but git apply needs the last newline,
+		// and the blank line ending a binary patch.
+		patch += "\n"
+		if strings.Contains(patch, "\nGIT binary patch\n") {
+			patch += "\n"
+		}
+		if _, err := s.saveSnapshot(label, patch); err != nil {
+			return "", fmt.Errorf("nothing was discarded, saving a snapshot failed: %w", err)
+		}
+	}
+	return s.runWithFallback(
+		append([]string{"restore", "--source=HEAD", "--staged", "--worktree", "--"}, specs...),
+		append([]string{"checkout", "HEAD", "--"}, specs...),
+	)
+}
+
+// IgnorePath adds path to the .gitignore at the top of the work tree,
+// anchored so that it matches only that path.
+func (s Service) IgnorePath(path string) (string, error) {
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return "", errors.New("path is empty")
+	}
+	root, _, err := s.repoDirs()
+	if err != nil {
+		return "", err
+	}
+	file := filepath.Join(root, ".gitignore")
+	data, err := os.ReadFile(file)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return "", err
+	}
+	line := gitignorePattern(path)
+	for _, existing := range strings.Split(string(data), "\n") {
+		if strings.TrimRight(existing, "\r") == line {
+			return "", fmt.Errorf("%s is already in .gitignore", path)
+		}
+	}
+	if len(data) > 0 && data[len(data)-1] != '\n' {
+		line = "\n" + line
+	}
+	f, err := os.OpenFile(file, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return "", err
+	}
+	if _, err := f.WriteString(line + "\n"); err != nil {
+		f.Close()
+		return "", err
+	}
+	return "", f.Close()
+}
+
+// gitignorePattern is the .gitignore line matching exactly path, relative to
+// the top of the work tree.
+func gitignorePattern(path string) string {
+	var b strings.Builder
+	b.WriteByte('/')
+	for _, r := range filepath.ToSlash(path) {
+		switch r {
+		case '*', '?', '[', '\\':
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	if strings.HasSuffix(path, " ") {
+		// Trailing spaces are ignored unless the last one is escaped.
+		line := b.String()
+		return line[:len(line)-1] + "\\ "
+	}
+	return b.String()
+}
+
+// LoadFileHistory lists the commits that changed path, newest first,
+// following it across renames.
+func (s Service) LoadFileHistory(path string) ([]Commit, error) {
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return nil, errors.New("path is empty")
+	}
+	out, _, err := s.runner.RunRead("--no-optional-locks", "log", graphLogFormat, "--follow", fmt.Sprintf("-n%d", maxFileHistory), "--", ":(top,literal)"+path)
+	if err != nil {
+		return nil, err
+	}
+	return parseGraphLog(out), nil
+}
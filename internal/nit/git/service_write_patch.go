@@ -0,0 +1,38 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+)
+
+var errEmptyPatch = errors.New("no changed lines selected")
+
+func (s Service) StagePatch(patch string) (string, error) {
+	return s.applyPatch(patch, "--cached")
+}
+
+func (s Service) UnstagePatch(patch string) (string, error) {
+	return s.applyPatch(patch, "--cached", "-R")
+}
+
+// DiscardPatch reverts patch in the work tree of path, after saving it in a
+// snapshot that RestoreSnapshot can apply again.
+func (s Service) DiscardPatch(path, patch string) (string, error) {
+	if patch == "" {
+		return "", errEmptyPatch
+	}
+	if _, err := s.saveSnapshot("Discard lines in "+path, patch); err != nil {
+		return "", fmt.Errorf("nothing was discarded, saving a snapshot failed: %w", err)
+	}
+	return s.applyPatch(patch, "-R")
+}
+
+func (s Service) applyPatch(patch string, flags ...string) (string, error) {
+	if patch == "" {
+		return "", errEmptyPatch
+	}
+	args := append([]string{"apply"}, flags...)
+	args = append(args, "--recount", "-")
+	_, cmd, err := s.runner.RunWithInput(patch, args...)
+	return cmd, err
+}
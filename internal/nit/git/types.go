@@ -1,10 +1,256 @@
 package git
 
+import "time"
+
+// ChangeEntry is one line of "git status --porcelain". Conflicted is set for
+// the unmerged states (DD, AU, UD, UA, DU, AA and UU) a merge leaves behind.
 type ChangeEntry struct {
-	X       byte
-	Y       byte
+	X          byte
+	Y          byte
+	Path       string
+	Raw        string
+	Staged     bool
+	Changed    bool
+	Conflicted bool
+}
+
+type DiffLineKind int
+
+const (
+	DiffLineHeader DiffLineKind = iota
+	DiffLineHunk
+	DiffLineContext
+	DiffLineAdded
+	DiffLineRemoved
+	DiffLineNote
+)
+
+// DiffHunk is one "@@ -a,b +c,d @@" section of a file diff. Lines keep their
+// leading ' ', '+', '-' or '\' marker exactly as git printed them.
+type DiffHunk struct {
+	Header   string
+	OldStart int
+	OldCount int
+	NewStart int
+	NewCount int
+	Lines    []string
+}
+
+type FileDiff struct {
+	Path   string
+	Staged bool
+	Header []string
+	Hunks  []DiffHunk
+	Binary bool
+}
+
+// DiffLine is a flattened, display-ready diff row. Hunk is -1 for file header
+// rows; Line is -1 for file header and hunk header rows.
+type DiffLine struct {
+	Kind DiffLineKind
+	Text string
+	Hunk int
+	Line int
+}
+
+// SignatureStatus summarizes git's %G? verdict on a commit signature. The
+// empty status means the signature was not checked.
+type SignatureStatus string
+
+const (
+	SignatureGood    SignatureStatus = "good"
+	SignatureBad     SignatureStatus = "bad"
+	SignatureUnknown SignatureStatus = "unknown"
+	SignatureNone    SignatureStatus = "none"
+)
+
+// Commit is one entry of the commit graph.
+type Commit struct {
+	Hash      string
+	ShortHash string
+	Parents   []string
+	Refs      []string
+	Author    string
+	Date      time.Time
+	Subject   string
+	Signature SignatureStatus
+}
+
+// CommitFile is a path touched by a commit. OldPath is set for renames and
+// copies.
+type CommitFile struct {
+	Status  string
 	Path    string
-	Raw     string
-	Staged  bool
-	Changed bool
+	OldPath string
+}
+
+type CommitDetail struct {
+	Hash           string
+	ShortHash      string
+	Parents        []string
+	Refs           string
+	AuthorName     string
+	AuthorEmail    string
+	AuthorDate     time.Time
+	CommitterName  string
+	CommitterEmail string
+	CommitDate     time.Time
+	Signature      SignatureStatus
+	Signer         string
+	SigningKey     string
+	Message        string
+	Files          []CommitFile
+}
+
+type BranchKind int
+
+const (
+	BranchLocal BranchKind = iota
+	BranchRemote
+	BranchTag
+)
+
+// Branch is a local branch, remote-tracking branch or tag. Name is the short
+// ref name, e.g. "main", "origin/main" or "v1.0". Head marks the checked out
+// branch; Upstream is the short name of a local branch's upstream, if any,
+// with Ahead and Behind counting commits against it. UpstreamGone is set when
+// the upstream was deleted from the remote.
+type Branch struct {
+	Kind         BranchKind
+	Name         string
+	Head         bool
+	Upstream     string
+	Ahead        int
+	Behind       int
+	UpstreamGone bool
+}
+
+// RepoSummary describes the repository and the checked out branch for the top
+// bar. Upstream is empty when the branch does not track one. State is the
+// operation git has left in progress, if any; during a rebase Step and Steps
+// count the todo list.
+type RepoSummary struct {
+	Repo     string
+	Branch   string
+	Upstream string
+	Ahead    int
+	Behind   int
+	State    RepoState
+	Step     int
+	Steps    int
+}
+
+// ResetMode is how far "git reset" goes: soft only moves the branch, mixed
+// also resets the index and hard also the working tree.
+type ResetMode string
+
+const (
+	ResetSoft  ResetMode = "soft"
+	ResetMixed ResetMode = "mixed"
+	ResetHard  ResetMode = "hard"
+)
+
+// ResetPreview is what a hard reset to a commit throws away: Files are the
+// uncommitted changes to tracked files and Commits counts the commits that
+// leave the branch, which stay reachable from the reflog.
+type ResetPreview struct {
+	Files   []CommitFile
+	Commits int
+}
+
+// Snapshot is what a discard threw away, saved first so it can be restored.
+// Stash is a stash-like commit from "git stash create" holding the index and
+// the tracked files; a partial discard keeps the discarded Patch instead.
+// Untracked lists the files copied next to the snapshot, relative to the
+// top of the work tree.
+type Snapshot struct {
+	ID        string    `json:"id"`
+	Created   time.Time `json:"created"`
+	Label     string    `json:"label"`
+	Stash     string    `json:"stash,omitempty"`
+	Patch     string    `json:"patch,omitempty"`
+	Untracked []string  `json:"untracked,omitempty"`
+}
+
+// RepoState is a multi-step operation that is stopped in the repository,
+// waiting to be continued or aborted.
+type RepoState string
+
+const (
+	RepoClean         RepoState = ""
+	RepoMerging       RepoState = "merge"
+	RepoRebasing      RepoState = "rebase"
+	RepoCherryPicking RepoState = "cherry-pick"
+	RepoReverting     RepoState = "revert"
+	RepoBisecting     RepoState = "bisect"
+)
+
+type RebaseAction string
+
+const (
+	RebasePick   RebaseAction = "pick"
+	RebaseReword RebaseAction = "reword"
+	RebaseEdit   RebaseAction = "edit"
+	RebaseSquash RebaseAction = "squash"
+	RebaseFixup  RebaseAction = "fixup"
+	RebaseDrop   RebaseAction = "drop"
+)
+
+// RebaseTodoItem is one line of an interactive rebase todo list.
+// FullMessage is the current message of the commit, subject and body, and
+// Message the new message of a reworded commit.
+type RebaseTodoItem struct {
+	Action      RebaseAction
+	Hash        string
+	ShortHash   string
+	Subject     string
+	FullMessage string
+	Message     string
+}
+
+// Stash is one entry of "git stash list". Ref is the stash@{n} name.
+type Stash struct {
+	Ref     string
+	Hash    string
+	Message string
+}
+
+type MergeMode string
+
+const (
+	MergeDefault     MergeMode = ""
+	MergeFastForward MergeMode = "ff-only"
+	MergeNoFF        MergeMode = "no-ff"
+)
+
+type ConflictChoice int
+
+const (
+	ConflictUnresolved ConflictChoice = iota
+	ConflictOurs
+	ConflictTheirs
+	ConflictBoth
+)
+
+// ConflictHunk is one block between conflict markers. Start, Separator and
+// End are the lines of its "<<<<<<<", "=======" and ">>>>>>>" markers; Base
+// is only set when the file was written with the diff3 or zdiff3 conflict
+// style.
+type ConflictHunk struct {
+	Start       int
+	Separator   int
+	End         int
+	OursLabel   string
+	TheirsLabel string
+	Ours        []string
+	Base        []string
+	Theirs      []string
+}
+
+// ConflictFile is a conflicted file as it is in the working tree, split into
+// lines, with the conflict blocks found in it.
+type ConflictFile struct {
+	Path  string
+	Lines []string
+	Hunks []ConflictHunk
 }
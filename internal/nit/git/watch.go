@@ -19,7 +19,7 @@ type FSWatcher struct {
 }
 
 func (s Service) NewFSWatcher() (*FSWatcher, error) {
-	root, _, err := s.runner.Run("--no-optional-locks", "rev-parse", "--show-toplevel")
+	root, _, err := s.runner.RunRead("--no-optional-locks", "rev-parse", "--show-toplevel")
 	if err != nil {
 		return nil, err
 	}
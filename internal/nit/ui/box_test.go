@@ -17,3 +17,18 @@ func TestFitTextKeepsVisibleWidthWithANSI(t *testing.T) {
 	}
 }
 
+func TestFitTextClosesTruncatedANSI(t *testing.T) {
+	s := "\x1b[32m+added line\x1b[39m"
+	got := fitText(s, 6, ' ')
+	if want := "\x1b[32m+ad\x1b[39m..."; got != want {
+		t.Fatalf("fitText() = %q, want %q", got, want)
+	}
+}
+
+func TestOverlayBlockKeepsANSIOutsideOverlay(t *testing.T) {
+	base := "ab\x1b[31mcdef\x1b[39mgh"
+	got := overlayBlock(base, "XY", 3, 0, 2)
+	if want := "ab\x1b[31mc\x1b[39mXY\x1b[31mf\x1b[39mgh"; got != want {
+		t.Fatalf("overlayBlock() = %q, want %q", got, want)
+	}
+}
@@ -2,22 +2,26 @@ package ui
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/zGIKS/nit/internal/nit/app"
+	g "github.com/zGIKS/nit/internal/nit/git"
 )
 
 func Render(state app.AppState) string {
 	commandActive := state.Focus == app.FocusCommand
 	changesActive := state.Focus == app.FocusChanges
+	diffActive := state.Focus == app.FocusDiff
 	graphActive := state.Focus == app.FocusGraph
 	branchesActive := state.Focus == app.FocusBranches
+	stashActive := state.Focus == app.FocusStash
 	commandLogActive := state.Focus == app.FocusCommandLog
 	changeSel, changeTotal := state.ChangesPosition()
 	graphSel, graphTotal := state.GraphPosition()
 	branchSel, branchTotal := state.BranchesPosition()
+	stashSel, stashTotal := state.StashPosition()
 
 	pushKeyNormal, pushKeyInCommand := resolvePushKeys(state)
-	commandText := resolveCommandText(state, commandActive, pushKeyNormal)
 
 	changeLines := make([]string, 0, len(state.Changes.Rows))
 	for _, r := range state.Changes.Rows {
@@ -36,21 +40,27 @@ func Render(state app.AppState) string {
 	}
 
 	topBar := buildTopBar(state, totalW)
-	commandBox := BoxView("Commit", commitW, 3, []string{commandText}, 0, 0, commandActive, "")
+	commandBox := commitBoxView(state, commitW, commandActive)
 	pushLabel := pushKeyNormal
 	if commandActive {
 		pushLabel = pushKeyInCommand
 	}
-	pushBox := BoxView("Push", pushW, 3, []string{pushLabel}, 0, 0, false, "")
+	pushBox := BoxView("Push", pushW, state.CommitBoxHeight(), []string{pushLabel}, 0, 0, false, "")
 	commandRow := HStack(commandBox, commitW, pushBox, pushW)
 	command := topBar + "\n" + commandRow
-	changes := BoxView("Changes", totalW, state.ChangesPaneHeight(), changeLines, state.Changes.Cursor, state.Changes.Offset, changesActive, fmt.Sprintf("%d of %d", changeSel, changeTotal))
-	graphPaneW, branchPaneW := state.GraphBranchesPaneWidths()
-	graphBox := BoxView("Commits - Reflog", graphPaneW, state.GraphPaneHeight(), state.Graph.Lines, state.Graph.Cursor, state.Graph.Offset, graphActive, fmt.Sprintf("%d of %d", graphSel, graphTotal))
+	changesPaneW, diffPaneW := state.ChangesDiffPaneWidths()
+	changesBox := BoxView("Changes", changesPaneW, state.ChangesPaneHeight(), changeLines, state.Changes.Cursor, state.Changes.Offset, changesActive, fmt.Sprintf("%d of %d", changeSel, changeTotal))
+	diffBox := diffPaneView(state, diffPaneW, state.ChangesPaneHeight(), diffActive)
+	changes := HStack(changesBox, changesPaneW, diffBox, diffPaneW)
+	graphPaneW, branchPaneW, stashPaneW := state.GraphRowPaneWidths()
+	graphBox := BoxView("Commits - Reflog", graphPaneW, state.GraphPaneHeight(), graphPaneLines(state), state.Graph.Cursor, state.Graph.Offset, graphActive, fmt.Sprintf("%d of %d", graphSel, graphTotal))
 	branchesBox := BoxView("Branches", branchPaneW, state.GraphPaneHeight(), state.Branches.Lines, state.Branches.Cursor, state.Branches.Offset, branchesActive, fmt.Sprintf("%d of %d", branchSel, branchTotal))
-	graph := HStack(graphBox, graphPaneW, branchesBox, branchPaneW)
+	stashBox := BoxView("Stash", stashPaneW, state.GraphPaneHeight(), state.Stash.Lines, state.Stash.Cursor, state.Stash.Offset, stashActive, fmt.Sprintf("%d of %d", stashSel, stashTotal))
+	graph := HStackMany([]string{graphBox, branchesBox, stashBox}, []int{graphPaneW, branchPaneW, stashPaneW})
 	commandLogFooter := ""
-	if state.LastErr != "" {
+	if state.OpOutput.Running {
+		commandLogFooter = opProgressView(state)
+	} else if state.LastErr != "" {
 		commandLogFooter = "error: " + state.LastErr
 	}
 	clCursor, clOffset := resolveCommandLogView(state, commandLogActive)
@@ -68,6 +78,54 @@ func Render(state app.AppState) string {
 		panelX, panelY, panelW, panelH := state.BranchCreatePanelRect()
 		out = overlayBlock(out, branchCreateModalView(state, panelW, panelH), panelX, panelY, panelW)
 	}
+	if state.Rebase.Open {
+		panelX, panelY, panelW, panelH := state.RebasePanelRect()
+		out = overlayBlock(out, rebaseModalView(state, panelW, panelH), panelX, panelY, panelW)
+	}
+	if state.Conflict.Open {
+		panelX, panelY, panelW, panelH := state.ConflictPanelRect()
+		out = overlayBlock(out, conflictModalView(state, panelW, panelH), panelX, panelY, panelW)
+	}
+	if state.Reset.Open {
+		panelX, panelY, panelW, panelH := state.ResetPanelRect()
+		out = overlayBlock(out, resetModalView(state, panelW, panelH), panelX, panelY, panelW)
+	}
+	if state.OpOutput.Open {
+		panelX, panelY, panelW, panelH := state.OpOutputPanelRect()
+		out = overlayBlock(out, opOutputModalView(state, panelW, panelH), panelX, panelY, panelW)
+	}
+	if state.Prompt.Open {
+		panelX, panelY, panelW, panelH := state.PromptPanelRect()
+		out = overlayBlock(out, promptModalView(state, panelW, panelH), panelX, panelY, panelW)
+	}
+	if state.CommitDetail.Open {
+		panelX, panelY, panelW, _ := state.CommitDetailPanelRect()
+		out = overlayBlock(out, commitDetailModalView(state, panelW), panelX, panelY, panelW)
+	}
+	if state.Dialog.Open {
+		panelX, panelY, panelW, panelH := state.DialogPanelRect()
+		out = overlayBlock(out, dialogModalView(state, panelW, panelH), panelX, panelY, panelW)
+	}
+	if state.Confirm.Open {
+		panelX, panelY, panelW, panelH := state.ConfirmPanelRect()
+		out = overlayBlock(out, confirmModalView(state, panelW, panelH), panelX, panelY, panelW)
+	}
+	return out
+}
+
+// graphPaneLines dims the badge of unsigned commits, so that it stays out of
+// the way of the good, bad and unknown ones.
+func graphPaneLines(state app.AppState) []string {
+	lines := state.GraphDisplayLines()
+	unsigned := g.SignatureBadge(g.SignatureNone)
+	out := make([]string, len(lines))
+	for i, line := range lines {
+		if i < len(state.Graph.Commits) && state.Graph.Commits[i].Signature == g.SignatureNone {
+			hash := state.Graph.Commits[i].ShortHash
+			line = strings.Replace(line, hash+" "+unsigned, hash+" "+ansiDim(unsigned), 1)
+		}
+		out[i] = line
+	}
 	return out
 }
 
@@ -0,0 +1,82 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/zGIKS/nit/internal/nit/app"
+	g "github.com/zGIKS/nit/internal/nit/git"
+)
+
+func commitDetailModalView(state app.AppState, width int) string {
+	cd := state.CommitDetail
+	title := cd.Title
+	headerH := state.CommitDetailHeaderHeight()
+	if !cd.Loaded {
+		header := BoxView(title, width, headerH, []string{"Loading commit..."}, -1, 0, true, "")
+		_, _, _, bodyH := state.CommitDetailBodyRect()
+		return header + "\n" + BoxView("", width, bodyH, nil, -1, 0, false, "")
+	}
+
+	header := BoxViewTitleRight(title, "Esc: close", width, headerH, commitDetailHeaderView(state.CommitDetailHeaderLines()), -1, 0, true, "")
+
+	_, _, _, bodyH := state.CommitDetailBodyRect()
+	filesW, diffW := state.CommitDetailPaneWidths()
+	fileLines := make([]string, 0, len(cd.Detail.Files))
+	for _, f := range cd.Detail.Files {
+		fileLines = append(fileLines, commitFileView(f))
+	}
+	if len(fileLines) == 0 {
+		fileLines = []string{"No file changes."}
+	}
+	fileCursor := cd.FileCursor
+	if cd.DiffFocus || len(cd.Detail.Files) == 0 {
+		fileCursor = -1
+	}
+	filesFooter := ""
+	if n := len(cd.Detail.Files); n > 0 {
+		filesFooter = fmt.Sprintf("%d of %d", cd.FileCursor+1, n)
+	}
+	files := BoxView("Files", filesW, bodyH, fileLines, fileCursor, cd.FileOffset, !cd.DiffFocus, filesFooter)
+
+	var diffLines []string
+	switch {
+	case len(cd.Detail.Files) == 0:
+		diffLines = nil
+	case !cd.Diff.Loaded:
+		diffLines = []string{"Loading diff..."}
+	case len(cd.Diff.Lines) == 0:
+		diffLines = []string{"No changes to show."}
+	default:
+		diffLines = diffLinesView(cd.Diff.Lines, nil)
+	}
+	diffCursor := cd.Diff.Cursor
+	if !cd.DiffFocus {
+		diffCursor = -1
+	}
+	diffFooter := ""
+	if n := len(cd.Diff.Lines); n > 0 {
+		diffFooter = fmt.Sprintf("%d of %d", cd.Diff.Cursor+1, n)
+	}
+	diff := BoxViewTitleRight("Diff", cd.Diff.Path, diffW, bodyH, diffLines, diffCursor, cd.Diff.Offset, cd.DiffFocus, diffFooter)
+	return header + "\n" + HStack(files, filesW, diff, diffW)
+}
+
+func commitDetailHeaderView(lines []string) []string {
+	out := make([]string, 0, len(lines))
+	for i, line := range lines {
+		line = strings.ReplaceAll(line, "\t", "    ")
+		if i == 0 {
+			line = ansiFg(line, 33)
+		}
+		out = append(out, line)
+	}
+	return out
+}
+
+func commitFileView(f g.CommitFile) string {
+	if f.OldPath != "" {
+		return f.Status + " " + f.OldPath + " → " + f.Path
+	}
+	return f.Status + " " + f.Path
+}
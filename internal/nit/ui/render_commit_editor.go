@@ -0,0 +1,81 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/zGIKS/nit/internal/nit/app"
+)
+
+// commitBoxView renders the Commit box. Unfocused it shows the subject on one
+// line; focused it becomes a multi-line editor with the subject on top, a
+// 50/72 column ruler and the body below. Amend details go above the subject.
+func commitBoxView(state app.AppState, width int, active bool) string {
+	height := state.CommitBoxHeight()
+	title := "Commit"
+	if label := state.CommitSignLabel(); label != "" {
+		title += " · " + label
+	}
+	if !active {
+		return BoxView(title, width, height, []string{resolveCommandText(state)}, 0, 0, false, "")
+	}
+
+	textW := max(1, commitContentWidth(state.Viewport.Width))
+	lines := state.CommitEditorLines()
+	line, col := state.CommitCursorLineCol()
+	info := state.CommitAmendLines()
+	rows := make([]string, 0, len(info)+len(lines)+1)
+	rows = append(rows, info...)
+	for i, text := range lines {
+		if i == line && !state.Command.SelectAll {
+			text = textInputViewport(text, col, false, textW)
+		}
+		rows = append(rows, text)
+		if i == 0 {
+			rows = append(rows, commitRuler(textW))
+		}
+	}
+	if state.Command.SelectAll && state.Command.Input != "" {
+		rows[len(info)] = "[" + rows[len(info)]
+		rows[len(rows)-1] += "]"
+	}
+
+	cursorRow := len(info) + line
+	if line > 0 {
+		cursorRow++
+	}
+	offset := max(0, cursorRow-(height-2)+1)
+
+	limit := app.CommitSubjectLimit
+	if line > 0 {
+		limit = app.CommitBodyLimit
+	}
+	count := fmt.Sprintf("%d/%d", len([]rune(lines[line])), limit)
+	return BoxViewTitleRight(title, count, width, height, rows, cursorRow, offset, true, commitLengthWarning(lines))
+}
+
+// commitRuler separates the subject from the body and marks the subject and
+// body length limits.
+func commitRuler(width int) string {
+	r := []rune(strings.Repeat("╌", min(width, app.CommitBodyLimit)))
+	for _, limit := range []int{app.CommitSubjectLimit, app.CommitBodyLimit} {
+		if limit <= len(r) {
+			r[limit-1] = '┆'
+		}
+	}
+	return string(r)
+}
+
+func commitLengthWarning(lines []string) string {
+	var warnings []string
+	if n := len([]rune(lines[0])); n > app.CommitSubjectLimit {
+		warnings = append(warnings, fmt.Sprintf("subject is %d columns", n))
+	}
+	for i, text := range lines[1:] {
+		if len([]rune(text)) > app.CommitBodyLimit {
+			warnings = append(warnings, fmt.Sprintf("body line %d over %d", i+1, app.CommitBodyLimit))
+			break
+		}
+	}
+	return strings.Join(warnings, ", ")
+}
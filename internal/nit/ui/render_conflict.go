@@ -0,0 +1,84 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/zGIKS/nit/internal/nit/app"
+	g "github.com/zGIKS/nit/internal/nit/git"
+)
+
+const conflictKeysHint = "o ours · t theirs · b both · Up/Down: next conflict"
+
+func conflictModalView(state app.AppState, width, height int) string {
+	c := state.Conflict
+	title := "Resolve " + c.Path
+	if !c.Loaded {
+		return BoxView(title, width, height, []string{"Loading file..."}, -1, 0, true, "")
+	}
+	if len(c.File.Hunks) == 0 {
+		lines := []string{"No conflict markers are left in this file.", "Enter marks it resolved."}
+		return BoxViewTitleRight(title, "Enter: mark resolved · Esc: close", width, height, lines, -1, 0, true, "")
+	}
+	footer := fmt.Sprintf("conflict %d of %d", c.Hunk+1, len(c.File.Hunks))
+	if left := state.ConflictsLeft(); left > 0 {
+		footer += fmt.Sprintf(" · %d left · %s", left, conflictKeysHint)
+	} else {
+		footer += " · all resolved · Enter: save and mark resolved"
+	}
+	lines := conflictLinesView(c.File, c.Choices)
+	return BoxViewTitleRight(title, "Enter: mark resolved · Esc: close", width, height, lines, state.ConflictCursorLine(), c.Offset, true, footer)
+}
+
+// conflictLinesView shows the whole file with its conflict blocks colored:
+// our side green, theirs blue, the base dimmed. A side that the block's
+// choice drops is dimmed too.
+func conflictLinesView(f g.ConflictFile, choices []g.ConflictChoice) []string {
+	out := make([]string, len(f.Lines))
+	for i, line := range f.Lines {
+		// Tabs have no display width of their own and would break box borders.
+		out[i] = strings.ReplaceAll(strings.TrimRight(line, "\r"), "\t", "    ")
+	}
+	for hi, h := range f.Hunks {
+		choice := g.ConflictUnresolved
+		if hi < len(choices) {
+			choice = choices[hi]
+		}
+		keepOurs := choice != g.ConflictTheirs
+		keepTheirs := choice != g.ConflictOurs
+		out[h.Start] = ansiFg(out[h.Start], 36) + "  " + conflictChoiceView(choice)
+		baseMarker := h.Start + len(h.Ours) + 1
+		for i := h.Start + 1; i < h.Separator; i++ {
+			switch {
+			case i >= baseMarker:
+				out[i] = ansiDim(out[i])
+			case keepOurs:
+				out[i] = ansiFg(out[i], 32)
+			default:
+				out[i] = ansiDim(out[i])
+			}
+		}
+		out[h.Separator] = ansiFg(out[h.Separator], 36)
+		for i := h.Separator + 1; i < h.End; i++ {
+			if keepTheirs {
+				out[i] = ansiFg(out[i], 34)
+			} else {
+				out[i] = ansiDim(out[i])
+			}
+		}
+		out[h.End] = ansiFg(out[h.End], 36)
+	}
+	return out
+}
+
+func conflictChoiceView(c g.ConflictChoice) string {
+	switch c {
+	case g.ConflictOurs:
+		return ansiFg("[keep ours]", 32)
+	case g.ConflictTheirs:
+		return ansiFg("[keep theirs]", 34)
+	case g.ConflictBoth:
+		return ansiFg("[keep both]", 35)
+	}
+	return ansiFg("[unresolved]", 33)
+}
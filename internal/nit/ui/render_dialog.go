@@ -0,0 +1,38 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/zGIKS/nit/internal/nit/app"
+)
+
+func dialogModalView(state app.AppState, width, height int) string {
+	d := state.Dialog
+	innerW := max(1, width-2)
+	row := func(text string) string {
+		return "│" + fitText(" "+text, innerW, ' ') + "│"
+	}
+	lines := []string{
+		"┌" + strings.Repeat("─", innerW) + "┐",
+		row(d.Title),
+		"├" + strings.Repeat("─", innerW) + "┤",
+	}
+	for _, line := range d.Lines {
+		lines = append(lines, row(line))
+	}
+	lines = append(lines, row(""))
+	start, end := state.DialogOptionsWindow()
+	for i := start; i < end; i++ {
+		opt := d.Options[i]
+		if i == d.Cursor {
+			lines = append(lines, "│"+ansiReverse(fitText(" > "+opt.Label, innerW, ' '))+"│")
+			continue
+		}
+		lines = append(lines, row("  "+opt.Label))
+	}
+	lines = append(lines, "└"+strings.Repeat("─", innerW)+"┘")
+	for len(lines) > height && len(lines) > 2 {
+		lines = append(lines[:len(lines)-2], lines[len(lines)-1])
+	}
+	return strings.Join(lines, "\n")
+}
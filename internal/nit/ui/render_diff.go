@@ -0,0 +1,71 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/zGIKS/nit/internal/nit/app"
+	g "github.com/zGIKS/nit/internal/nit/git"
+)
+
+func diffPaneView(state app.AppState, width, height int, active bool) string {
+	titleRight := ""
+	if state.Diff.Path != "" {
+		titleRight = state.Diff.Path
+		if state.Diff.Staged {
+			titleRight += " (staged)"
+		}
+	}
+	var lines []string
+	switch {
+	case state.Diff.Path == "":
+		lines = []string{"Select a file to preview its changes."}
+	case !state.Diff.Loaded:
+		lines = []string{"Loading diff..."}
+	case len(state.Diff.Lines) == 0:
+		lines = []string{"No changes to show."}
+	default:
+		lines = diffLinesView(state.Diff.Lines, state.Diff.Marked)
+	}
+	cursor := state.Diff.Cursor
+	if !active {
+		cursor = -1
+	}
+	sel, total := state.DiffPosition()
+	footer := fmt.Sprintf("%d of %d", sel, total)
+	if n := len(state.Diff.Marked); n > 0 {
+		footer += fmt.Sprintf(" · %d marked", n)
+	}
+	return BoxViewTitleRight("Diff", titleRight, width, height, lines, cursor, state.Diff.Offset, active, footer)
+}
+
+func diffLinesView(lines []g.DiffLine, marked map[int]bool) []string {
+	out := make([]string, 0, len(lines))
+	for i, l := range lines {
+		text := diffLineView(l)
+		if marked[i] {
+			text = ansiReverse(text)
+		}
+		out = append(out, text)
+	}
+	return out
+}
+
+func diffLineView(l g.DiffLine) string {
+	// Tabs have no display width of their own and would break box borders.
+	text := strings.ReplaceAll(l.Text, "\t", "    ")
+	switch l.Kind {
+	case g.DiffLineHeader:
+		return ansiDim(text)
+	case g.DiffLineHunk:
+		return ansiFg(text, 36)
+	case g.DiffLineAdded:
+		return ansiFg(text, 32)
+	case g.DiffLineRemoved:
+		return ansiFg(text, 31)
+	case g.DiffLineNote:
+		return ansiDim(text)
+	default:
+		return text
+	}
+}
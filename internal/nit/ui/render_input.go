@@ -22,16 +22,17 @@ func resolvePushKeys(state app.AppState) (normal, inCommand string) {
 	return normal, inCommand
 }
 
-func resolveCommandText(state app.AppState, commandActive bool, pushKeyNormal string) string {
+func resolveCommandText(state app.AppState) string {
 	focusKey := state.Keys.DisplayBinding(app.ActionFocusCommand)
 	if focusKey == "" {
 		focusKey = "c"
 	}
-	if commandActive {
-		return commandLineViewport(state, max(1, commitContentWidth(state.Viewport.Width)))
-	}
 	if state.Command.Input != "" {
-		return state.Command.Input
+		lines := state.CommitEditorLines()
+		if len(lines) > 1 {
+			return fmt.Sprintf("%s (+%d lines)", lines[0], len(lines)-1)
+		}
+		return lines[0]
 	}
 	return fmt.Sprintf("Message (%s focus, Enter commit)", focusKey)
 }
@@ -48,10 +49,6 @@ func commitContentWidth(totalWidth int) int {
 	return commitW - 6
 }
 
-func commandLineViewport(state app.AppState, width int) string {
-	return textInputViewport(state.Command.Input, state.Command.Cursor, state.Command.SelectAll, width)
-}
-
 func textInputViewport(value string, cursor int, selectAll bool, width int) string {
 	full := textInputLineWithCaret(value, cursor, selectAll)
 	if width < 4 {
@@ -87,6 +87,49 @@ func branchCreateModalView(state app.AppState, width, height int) string {
 	return strings.Join(lines, "\n")
 }
 
+// confirmModalView is the confirmation modal of a destructive operation.
+// What the operation loses is listed in red.
+func confirmModalView(state app.AppState, width, height int) string {
+	c := state.Confirm
+	innerW := max(1, width-2)
+	row := func(text string) string {
+		return "│" + fitText(" "+text, innerW, ' ') + "│"
+	}
+	lines := []string{
+		"┌" + strings.Repeat("─", innerW) + "┐",
+		row(c.Title),
+		"├" + strings.Repeat("─", innerW) + "┤",
+	}
+	for _, line := range state.ConfirmLines() {
+		lines = append(lines, row(line))
+	}
+	for _, item := range state.ConfirmItems() {
+		lines = append(lines, "│"+ansiFg(fitText("   "+item, innerW, ' '), 31)+"│")
+	}
+	if c.Note != "" {
+		lines = append(lines, row(ansiDim(c.Note)))
+	}
+	lines = append(lines, row(""))
+	if c.TypeYes {
+		input := textInputViewport(c.Input, c.Cursor, c.SelectAll, max(1, innerW-9))
+		lines = append(lines, row(`Type "yes": `+input))
+		lines = append(lines, row(ansiDim("Enter: "+c.Label+" · Esc: cancel")))
+	} else {
+		for i, label := range []string{c.Label, "Cancel"} {
+			if i == c.Choice {
+				lines = append(lines, "│"+ansiReverse(fitText(" > "+label, innerW, ' '))+"│")
+				continue
+			}
+			lines = append(lines, row("  "+label))
+		}
+	}
+	lines = append(lines, "└"+strings.Repeat("─", innerW)+"┘")
+	for len(lines) > height && len(lines) > 2 {
+		lines = append(lines[:len(lines)-2], lines[len(lines)-1])
+	}
+	return strings.Join(lines, "\n")
+}
+
 func overlayBlock(base, overlay string, x, y, width int) string {
 	if base == "" || overlay == "" || x < 0 || y < 0 || width <= 0 {
 		return base
@@ -98,16 +141,12 @@ func overlayBlock(base, overlay string, x, y, width int) string {
 		if row < 0 || row >= len(baseLines) {
 			continue
 		}
-		bl := []rune(baseLines[row])
-		if len(bl) < x {
-			bl = append(bl, []rune(strings.Repeat(" ", x-len(bl)))...)
-		}
-		end := x + width
-		if len(bl) < end {
-			bl = append(bl, []rune(strings.Repeat(" ", end-len(bl)))...)
+		bl := baseLines[row]
+		left := truncateDisplayWidth(bl, x)
+		if w := displayWidth(left); w < x {
+			left += strings.Repeat(" ", x-w)
 		}
-		left := string(bl[:x])
-		right := string(bl[end:])
+		right := dropDisplayWidth(bl, x+width)
 		baseLines[row] = left + ol + right
 	}
 	return strings.Join(baseLines, "\n")
@@ -0,0 +1,50 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/zGIKS/nit/internal/nit/app"
+)
+
+const progressBarWidth = 20
+
+func opOutputModalView(state app.AppState, width, height int) string {
+	o := state.OpOutput
+	lines := o.Lines
+	if len(lines) == 0 && o.Running {
+		lines = []string{ansiDim("Waiting for output...")}
+	}
+	titleRight := "Esc: close"
+	footer := ""
+	switch {
+	case o.Running:
+		titleRight = "Esc: hide"
+		footer = opProgressView(state)
+	case state.CanRetryWithoutHooks():
+		footer = "n: retry with --no-verify"
+	}
+	return BoxViewTitleRight(state.OpOutputTitle(), titleRight, width, height, lines, -1, o.Offset, true, footer)
+}
+
+// opProgressView sums up the running operation on one line, e.g.
+// "git fetch · Receiving objects ████░░░░ 45% · Ctrl+G: cancel".
+func opProgressView(state app.AppState) string {
+	o := state.OpOutput
+	text := "git " + state.OpOutputVerb()
+	if o.Cancelling {
+		return text + " · cancelling..."
+	}
+	if p := o.Progress; p.Phase != "" {
+		percent := max(0, min(p.Percent, 100))
+		filled := percent * progressBarWidth / 100
+		bar := strings.Repeat("█", filled) + strings.Repeat("░", progressBarWidth-filled)
+		text += fmt.Sprintf(" · %s %s %d%%", p.Phase, bar, percent)
+	} else {
+		text += " · running..."
+	}
+	if key := state.Keys.DisplayBinding(app.ActionCancelOperation); key != "" {
+		text += " · " + key + ": cancel"
+	}
+	return text
+}
@@ -0,0 +1,68 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/zGIKS/nit/internal/nit/app"
+)
+
+func promptModalView(state app.AppState, width, height int) string {
+	p := state.Prompt
+	innerW := max(1, width-2)
+	row := func(text string) string {
+		return "│" + fitText(" "+text, innerW, ' ') + "│"
+	}
+	lines := []string{
+		"┌" + strings.Repeat("─", innerW) + "┐",
+		row(p.Title),
+		"├" + strings.Repeat("─", innerW) + "┤",
+		row(p.Label),
+	}
+	if p.Multiline {
+		for _, text := range promptInputLines(state, max(1, innerW-1)) {
+			lines = append(lines, row(text))
+		}
+	} else {
+		lines = append(lines, row(textInputViewport(p.Input, p.Cursor, p.SelectAll, max(1, innerW-1))))
+	}
+	hint := "Enter: confirm · Esc: cancel"
+	if p.Multiline {
+		hint += " · Ctrl+J: new line"
+	}
+	if p.OptionLabel != "" {
+		box := "[ ]"
+		if p.Option {
+			box = "[x]"
+		}
+		lines = append(lines, row(box+" "+p.OptionLabel))
+		hint += " · Tab: toggle option"
+	}
+	lines = append(lines, row(ansiDim(hint)))
+	lines = append(lines, "└"+strings.Repeat("─", innerW)+"┘")
+	for len(lines) > height && len(lines) > 2 {
+		lines = append(lines[:len(lines)-2], lines[len(lines)-1])
+	}
+	return strings.Join(lines, "\n")
+}
+
+// promptInputLines is the window of a multi-line prompt input that keeps the
+// cursor line in view.
+func promptInputLines(state app.AppState, width int) []string {
+	p := state.Prompt
+	texts := strings.Split(p.Input, "\n")
+	line, col := state.PromptCursorLineCol()
+	if line < len(texts) && !p.SelectAll {
+		texts[line] = textInputViewport(texts[line], col, false, width)
+	}
+	if p.SelectAll && p.Input != "" {
+		texts[0] = "[" + texts[0]
+		texts[len(texts)-1] += "]"
+	}
+	rows := state.PromptInputRows()
+	start := max(0, min(line-rows+1, len(texts)-rows))
+	out := texts[start:min(len(texts), start+rows)]
+	for len(out) < rows {
+		out = append(out, "")
+	}
+	return out
+}
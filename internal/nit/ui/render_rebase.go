@@ -0,0 +1,43 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/zGIKS/nit/internal/nit/app"
+	g "github.com/zGIKS/nit/internal/nit/git"
+)
+
+const rebaseKeysHint = "p pick · r reword · e edit · s squash · f fixup · d drop · J/K move"
+
+func rebaseModalView(state app.AppState, width, height int) string {
+	r := state.Rebase
+	title := "Interactive rebase onto " + r.BaseShort
+	if !r.Loaded {
+		return BoxView(title, width, height, []string{"Loading commits..."}, -1, 0, true, "")
+	}
+	lines := make([]string, 0, len(r.Items))
+	for _, it := range r.Items {
+		lines = append(lines, rebaseItemView(it))
+	}
+	return BoxViewTitleRight(title, "Enter: start · Esc: cancel", width, height, lines, r.Cursor, r.Offset, true, rebaseKeysHint)
+}
+
+func rebaseItemView(it g.RebaseTodoItem) string {
+	action := fmt.Sprintf("%-6s", it.Action)
+	switch it.Action {
+	case g.RebaseDrop:
+		return ansiDim(action + " " + it.ShortHash + " " + it.Subject)
+	case g.RebaseReword:
+		subject := it.Subject
+		if it.Message != "" {
+			subject, _, _ = strings.Cut(it.Message, "\n")
+		}
+		return ansiFg(action, 33) + " " + it.ShortHash + " " + subject
+	case g.RebaseEdit:
+		return ansiFg(action, 35) + " " + it.ShortHash + " " + it.Subject
+	case g.RebaseSquash, g.RebaseFixup:
+		return ansiFg(action, 36) + " " + it.ShortHash + " " + it.Subject
+	}
+	return ansiFg(action, 32) + " " + it.ShortHash + " " + it.Subject
+}
@@ -0,0 +1,10 @@
+package ui
+
+import (
+	"github.com/zGIKS/nit/internal/nit/app"
+)
+
+func resetModalView(state app.AppState, width, height int) string {
+	lines, cursor := state.ResetLines()
+	return BoxViewTitleRight(state.ResetTitle(), "Enter: choose · Esc: cancel", width, height, lines, cursor, 0, true, "")
+}
@@ -8,23 +8,7 @@ import (
 )
 
 func buildTopBar(state app.AppState, totalW int) string {
-	repoName := state.RepoName
-	if repoName == "" {
-		repoName = "unknown"
-	}
-	branchName := state.BranchName
-	if branchName == "" {
-		branchName = "-"
-	}
-	sep := strings.TrimSpace(state.RepoBranchSeparator)
-	if sep == "" {
-		sep = "->"
-	}
-	repoText := strings.TrimSpace(
-		strings.TrimSpace(state.RepoLabel+" "+repoName) +
-			" " + sep + " " +
-			strings.TrimSpace(state.BranchLabel+" "+branchName),
-	)
+	repoText := state.TopBarRepoText()
 	createText := strings.TrimSpace(state.BranchesCreateButtonLabel())
 	fetchText := strings.TrimSpace(state.FetchLabel)
 	menuText := strings.TrimSpace(state.MenuLabel)
@@ -90,9 +74,22 @@ func buildTopBar(state app.AppState, totalW int) string {
 		gapW = 1
 	}
 	spacerLine := strings.Repeat(" ", gapW)
-	spacer := spacerLine + "\n" + spacerLine + "\n" + spacerLine
+	spacer := spacerLine + "\n" + repoStateBannerView(state.RepoStateBanner(), gapW) + "\n" + spacerLine
 	return HStackMany(
 		[]string{leftTop, spacer, rightTop},
 		[]int{repoW, gapW, rightTopW},
 	)
 }
+
+// repoStateBannerView centres the in-progress banner in the gap between the
+// repository box and the buttons.
+func repoStateBannerView(banner string, width int) string {
+	if banner == "" || width < 5 {
+		return strings.Repeat(" ", width)
+	}
+	text := fitText(" "+banner+" ", width-2, ' ')
+	text = strings.TrimRight(text, " ") + " "
+	left := (width - displayWidth(text)) / 2
+	right := width - left - displayWidth(text)
+	return strings.Repeat(" ", left) + ansiReverse(ansiFg(text, 33)) + strings.Repeat(" ", right)
+}
@@ -31,7 +31,8 @@ func truncateDisplayWidth(s string, width int) string {
 	}
 	var b strings.Builder
 	cur := 0
-	for i := 0; i < len(s); {
+	i := 0
+	for i < len(s) {
 		if end, ok := ansiSeqEnd(s, i); ok {
 			b.WriteString(s[i:end])
 			i = end
@@ -51,6 +52,41 @@ func truncateDisplayWidth(s string, width int) string {
 		cur += rw
 		i += size
 	}
+	// Keep the escape sequences of the dropped tail so styles opened before
+	// the cut are still closed.
+	for i < len(s) {
+		if end, ok := ansiSeqEnd(s, i); ok {
+			b.WriteString(s[i:end])
+			i = end
+			continue
+		}
+		i++
+	}
+	return b.String()
+}
+
+// dropDisplayWidth removes the first width visible columns of s. Escape
+// sequences found in the removed part are kept so styles that started there
+// still apply to the rest.
+func dropDisplayWidth(s string, width int) string {
+	var b strings.Builder
+	cur := 0
+	i := 0
+	for i < len(s) && cur < width {
+		if end, ok := ansiSeqEnd(s, i); ok {
+			b.WriteString(s[i:end])
+			i = end
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		cur += runewidth.RuneWidth(r)
+		i += size
+	}
+	if cur > width {
+		// A wide rune straddled the cut; pad its visible remainder.
+		b.WriteString(strings.Repeat(" ", cur-width))
+	}
+	b.WriteString(s[i:])
 	return b.String()
 }
 
@@ -87,3 +123,15 @@ func ansiSeqEnd(s string, i int) (int, bool) {
 func ansiUnderline(s string) string {
 	return fmt.Sprintf("\x1b[4m%s\x1b[24m", s)
 }
+
+func ansiFg(s string, color int) string {
+	return fmt.Sprintf("\x1b[%dm%s\x1b[39m", color, s)
+}
+
+func ansiReverse(s string) string {
+	return fmt.Sprintf("\x1b[7m%s\x1b[27m", s)
+}
+
+func ansiDim(s string) string {
+	return fmt.Sprintf("\x1b[2m%s\x1b[22m", s)
+}